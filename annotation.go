@@ -0,0 +1,116 @@
+package g11n
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"strconv"
+	"unicode/utf8"
+)
+
+// Annotation tags.
+const (
+	descriptionTag = "desc"
+	maxLengthTag   = "maxlen"
+)
+
+// Error message patterns.
+const (
+	wrongMaxLengthMessage    = "Wrong max length '%v' of message '%v'."
+	maxLengthExceededMessage = "Message '%v' in locale '%v' is %v characters long, expected at most %v."
+)
+
+// Annotation holds the translator-facing information of a message that is
+// declared in the desc and maxlen tags of its field.
+//
+//	type M struct {
+//		Pay func() string `default:"Pay" desc:"Button on checkout page" maxlen:"20"`
+//	}
+type Annotation struct {
+
+	// Description tells translators where and how the message is used.
+	Description string
+
+	// MaxLength is the maximum number of characters of a translation of
+	// the message, or 0 if the length is not limited.
+	MaxLength int
+}
+
+// MaxLengthPolicy selects how translations longer than the max length of
+// their messages are handled when a locale is loaded.
+type MaxLengthPolicy int
+
+// Max length policies.
+const (
+	// WarnMaxLength logs a warning for each translation that is too long.
+	WarnMaxLength MaxLengthPolicy = iota
+
+	// PanicMaxLength panics on the first translation that is too long.
+	PanicMaxLength
+
+	// IgnoreMaxLength accepts translations of any length.
+	IgnoreMaxLength
+)
+
+// parseAnnotation extracts the annotation of a message field.
+func parseAnnotation(messageKey string, tag reflect.StructTag) Annotation {
+	annotation := Annotation{
+		Description: tag.Get(descriptionTag),
+	}
+
+	if maxLength, ok := tag.Lookup(maxLengthTag); ok {
+		length, err := strconv.Atoi(maxLength)
+		if err != nil || length < 1 {
+			panic(fmt.Sprintf(wrongMaxLengthMessage, maxLength, messageKey))
+		}
+		annotation.MaxLength = length
+	}
+
+	return annotation
+}
+
+// Annotation returns the annotation of an initialized message.
+func (mf *MessageFactory) Annotation(messageKey string) (Annotation, bool) {
+	descriptor, ok := mf.message(messageKey)
+	return descriptor.Annotation, ok
+}
+
+// SetMaxLengthPolicy selects how translations longer than the max length of
+// their messages are handled. The default policy is WarnMaxLength.
+func (mf *MessageFactory) SetMaxLengthPolicy(policy MaxLengthPolicy) {
+	mf.maxLengthPolicy = policy
+}
+
+// checkMaxLengths enforces the max length of the translations of messages
+// in a dictionary. All initialized messages are checked if no keys are given.
+func (mf *MessageFactory) checkMaxLengths(d *dictionary, messageKeys ...string) {
+	if mf.maxLengthPolicy == IgnoreMaxLength {
+		return
+	}
+
+	if len(messageKeys) == 0 {
+		for _, descriptor := range mf.Messages() {
+			messageKeys = append(messageKeys, descriptor.Key)
+		}
+	}
+
+	for _, messageKey := range messageKeys {
+		descriptor, _ := mf.message(messageKey)
+		maxLength := descriptor.MaxLength
+		translation, ok := d.messages[messageKey]
+		if maxLength == 0 || !ok {
+			continue
+		}
+
+		length := utf8.RuneCountInString(translation)
+		if length <= maxLength {
+			continue
+		}
+
+		message := fmt.Sprintf(maxLengthExceededMessage, messageKey, d.tag, length, maxLength)
+		if mf.maxLengthPolicy == PanicMaxLength {
+			panic(message)
+		}
+		log.Print(message)
+	}
+}
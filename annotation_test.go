@@ -0,0 +1,137 @@
+package g11n_test
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+type AnnotatedMessages struct {
+	Pay    func() string `default:"Pay" desc:"Button on checkout page" maxlen:"5"`
+	Title  string        `default:"Checkout" maxlen:"10"`
+	Thanks func() string `default:"Thank you!"`
+}
+
+// captureLog returns the log output of a func.
+func captureLog(f func()) string {
+	var output bytes.Buffer
+
+	writer, flags := log.Writer(), log.Flags()
+	defer log.SetOutput(writer)
+	defer log.SetFlags(flags)
+
+	log.SetOutput(&output)
+	log.SetFlags(0)
+
+	f()
+
+	return output.String()
+}
+
+func TestAnnotation(t *testing.T) {
+	factory := New()
+	factory.Init(&AnnotatedMessages{})
+
+	annotation, ok := factory.Annotation("AnnotatedMessages.Pay")
+	if !ok || annotation != (Annotation{Description: "Button on checkout page", MaxLength: 5}) {
+		t.Errorf("Annotation is not correct: %+v", annotation)
+	}
+
+	annotation, ok = factory.Annotation("AnnotatedMessages.Thanks")
+	if !ok || annotation != (Annotation{}) {
+		t.Errorf("Annotation is not correct: %+v", annotation)
+	}
+
+	if _, ok := factory.Annotation("AnnotatedMessages.Missing"); ok {
+		t.Errorf("Annotation of unknown message is found.")
+	}
+}
+
+func TestWrongMaxLength(t *testing.T) {
+	defer MustPanic(t, "Wrong max length 'short' of message 'M.Pay'.")
+
+	type M struct {
+		Pay func() string `default:"Pay" maxlen:"short"`
+	}
+
+	New().Init(&M{})
+}
+
+func TestMaxLengthWarning(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "AnnotatedMessages.Pay": "Плащане",
+	  "AnnotatedMessages.Title": "Плащане",
+	  "AnnotatedMessages.Thanks": "Благодарим ви за покупката!"
+	}
+`))
+	factory.Init(&AnnotatedMessages{})
+
+	output := captureLog(func() {
+		factory.LoadLocale(language.Bulgarian)
+	})
+
+	testMessage(t, output,
+		"Message 'AnnotatedMessages.Pay' in locale 'bg' is 7 characters long, expected at most 5.\n")
+}
+
+func TestMaxLengthWarningOnInit(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "AnnotatedMessages.Pay": "Плащане",
+	  "AnnotatedMessages.Title": "Плащане",
+	  "AnnotatedMessages.Thanks": "Благодарим ви за покупката!"
+	}
+`))
+	factory.LoadLocale(language.Bulgarian)
+
+	output := captureLog(func() {
+		factory.Init(&AnnotatedMessages{})
+	})
+
+	testMessage(t, output,
+		"Message 'AnnotatedMessages.Pay' in locale 'bg' is 7 characters long, expected at most 5.\n")
+}
+
+func TestMaxLengthPanic(t *testing.T) {
+	defer MustPanic(t, "Message 'AnnotatedMessages.Pay' in locale 'bg' is 7 characters long, expected at most 5.")
+
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "AnnotatedMessages.Pay": "Плащане",
+	  "AnnotatedMessages.Title": "Плащане",
+	  "AnnotatedMessages.Thanks": "Благодарим ви за покупката!"
+	}
+`))
+	factory.SetMaxLengthPolicy(PanicMaxLength)
+	factory.Init(&AnnotatedMessages{})
+	factory.LoadLocale(language.Bulgarian)
+}
+
+func TestMaxLengthIgnore(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "AnnotatedMessages.Pay": "Плащане",
+	  "AnnotatedMessages.Title": "Плащане",
+	  "AnnotatedMessages.Thanks": "Благодарим ви за покупката!"
+	}
+`))
+	factory.SetMaxLengthPolicy(IgnoreMaxLength)
+	m := factory.Init(&AnnotatedMessages{}).(*AnnotatedMessages)
+
+	output := captureLog(func() {
+		factory.LoadLocale(language.Bulgarian)
+	})
+
+	testMessage(t, output, "")
+	testMessage(t, m.Pay(), "Плащане")
+}
@@ -0,0 +1,168 @@
+package g11n_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+type BenchmarkMessages struct {
+	Title   func() string                        `default:"Welcome!"`
+	Hello   func(string) string                  `default:"Hi %v!"`
+	Cats    func(int) string                     `default:"You have %d cats."`
+	Order   func(string, int, float64) string    `default:"%v ordered %d items for %.2f."`
+	Reorder func(string, string) string          `default:"%[2]v and %[1]v"`
+	Date    func(string, time.Time) string       `default:"%v on {2, date, short}"`
+	Left    func(Gender) string                  `default:"{1, select, female {She} male {He} other {They}} left."`
+	Safe    func(string) SafeHTML                `default:"Hi <b>%v</b>!"`
+	Greet   func(context.Context, string) string `default:"Hi %v!"`
+	Strict  func(string) (string, error)         `default:"Hi %v!"`
+	Friends func(...string) string               `default:"%v, %v and %v"`
+	Failed  func(string) error                   `default:"Order of %v failed."`
+}
+
+func benchmarkFactory() (*MessageFactory, *BenchmarkMessages) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "BenchmarkMessages.Hello": "Здравей %v!",
+	  "BenchmarkMessages.Cats": "Имате %d котки."
+	}
+`))
+
+	return factory, factory.Init(&BenchmarkMessages{}).(*BenchmarkMessages)
+}
+
+func BenchmarkMessageNoParams(b *testing.B) {
+	_, m := benchmarkFactory()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Title()
+	}
+}
+
+func BenchmarkMessageString(b *testing.B) {
+	_, m := benchmarkFactory()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Hello("Bob")
+	}
+}
+
+func BenchmarkMessageStringLocalized(b *testing.B) {
+	factory, m := benchmarkFactory()
+	factory.LoadLocale(language.Bulgarian)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Hello("Иван")
+	}
+}
+
+func BenchmarkMessageInt(b *testing.B) {
+	_, m := benchmarkFactory()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Cats(1234)
+	}
+}
+
+func BenchmarkMessageMultipleParams(b *testing.B) {
+	_, m := benchmarkFactory()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Order("Bob", 3, 12.5)
+	}
+}
+
+func BenchmarkMessageReordered(b *testing.B) {
+	_, m := benchmarkFactory()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Reorder("Ana", "Bob")
+	}
+}
+
+func BenchmarkMessagePlaceholder(b *testing.B) {
+	_, m := benchmarkFactory()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Date("Bob", testTime)
+	}
+}
+
+func BenchmarkMessageSelect(b *testing.B) {
+	_, m := benchmarkFactory()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Left(Female)
+	}
+}
+
+func BenchmarkMessageSafeHTML(b *testing.B) {
+	_, m := benchmarkFactory()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Safe("<Bob>")
+	}
+}
+
+func BenchmarkMessageContext(b *testing.B) {
+	_, m := benchmarkFactory()
+	ctx := WithLocale(context.Background(), language.Bulgarian)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Greet(ctx, "Иван")
+	}
+}
+
+func BenchmarkMessageError(b *testing.B) {
+	_, m := benchmarkFactory()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Strict("Bob")
+	}
+}
+
+func BenchmarkMessageVariadic(b *testing.B) {
+	_, m := benchmarkFactory()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		m.Friends("Ana", "Bob", "Eve")
+	}
+}
+
+func BenchmarkTranslate(b *testing.B) {
+	factory, _ := benchmarkFactory()
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		factory.Translate("BenchmarkMessages.Hello", "Bob")
+	}
+}
+
+func BenchmarkErrorLocalize(b *testing.B) {
+	_, m := benchmarkFactory()
+	err := m.Failed("Bob").(LocalizedError)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		err.Localize(language.Bulgarian)
+	}
+}
@@ -0,0 +1,132 @@
+package g11n
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+
+	"golang.org/x/text/language"
+)
+
+// Error message patterns.
+const (
+	wrongCacheSizeMessage = "Wrong dictionary cache size %v. Expected 0 or more."
+)
+
+// dictionaryCache holds the loaded dictionaries of locales. The least
+// recently used dictionary is evicted when the cache is bounded and full.
+type dictionaryCache struct {
+	mutex   sync.Mutex
+	size    int
+	entries map[language.Tag]*list.Element
+	usage   *list.List
+}
+
+// newDictionaryCache creates an unbounded dictionary cache.
+func newDictionaryCache() *dictionaryCache {
+	return &dictionaryCache{
+		entries: map[language.Tag]*list.Element{},
+		usage:   list.New(),
+	}
+}
+
+// get returns the cached dictionary of a locale and marks it as used.
+func (c *dictionaryCache) get(tag language.Tag) (*dictionary, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	element, ok := c.entries[tag]
+	if !ok {
+		return nil, false
+	}
+
+	c.usage.MoveToFront(element)
+
+	return element.Value.(*dictionary), true
+}
+
+// put caches the dictionary of a locale.
+func (c *dictionaryCache) put(dictionary *dictionary) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.entries[dictionary.tag]; ok {
+		element.Value = dictionary
+		c.usage.MoveToFront(element)
+		return
+	}
+
+	c.entries[dictionary.tag] = c.usage.PushFront(dictionary)
+	c.evict()
+}
+
+// remove removes the dictionary of a locale from the cache.
+func (c *dictionaryCache) remove(tag language.Tag) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.entries[tag]; ok {
+		c.usage.Remove(element)
+		delete(c.entries, tag)
+	}
+}
+
+// resize bounds the number of cached dictionaries. Zero means unbounded.
+func (c *dictionaryCache) resize(size int) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.size = size
+	c.evict()
+}
+
+// evict removes the least recently used dictionaries above the size.
+func (c *dictionaryCache) evict() {
+	for c.size > 0 && c.usage.Len() > c.size {
+		element := c.usage.Back()
+		c.usage.Remove(element)
+		delete(c.entries, element.Value.(*dictionary).tag)
+	}
+}
+
+// SetCacheSize bounds the number of locale dictionaries kept in memory by
+// a message factory. The least recently used dictionary is evicted when the
+// cache is full and is loaded again from its file when it is needed. Zero,
+// the default, keeps all loaded dictionaries.
+func (mf *MessageFactory) SetCacheSize(size int) {
+	if size < 0 {
+		panic(fmt.Sprintf(wrongCacheSizeMessage, size))
+	}
+
+	mf.dictionaries.resize(size)
+}
+
+// Preload loads the dictionaries of all registered locales into the cache,
+// so that LoadLocale and context-aware message funcs do not read locale
+// files afterwards. Only the most recently registered locales are kept if
+// the cache is smaller than the number of locales.
+func (mf *MessageFactory) Preload() {
+	for _, tag := range mf.localesOrder {
+		mf.cachedDictionary(tag, mf.locales[tag])
+	}
+}
+
+// Invalidate removes the dictionary of a locale from the cache, e.g. after
+// its file has changed. The dictionary is loaded again the next time it is
+// needed. The active locale is not affected until it is loaded again.
+func (mf *MessageFactory) Invalidate(tag language.Tag) {
+	mf.dictionaries.remove(tag)
+}
+
+// cachedDictionary returns the dictionary of a registered locale, loading
+// it if it is not cached.
+func (mf *MessageFactory) cachedDictionary(tag language.Tag, locale localeInfo) *dictionary {
+	if dictionary, ok := mf.dictionaries.get(tag); ok {
+		return dictionary
+	}
+
+	dictionary := mf.loadDictionary(tag, locale)
+	mf.dictionaries.put(dictionary)
+
+	return dictionary
+}
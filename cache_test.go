@@ -0,0 +1,105 @@
+package g11n_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+type CacheMessages struct {
+	Animal string `default:"cat"`
+}
+
+func writeFile(path, content string) {
+	if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+		panic(err)
+	}
+}
+
+func TestLoadLocaleCache(t *testing.T) {
+	files := map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"CacheMessages.Animal": "котка"}`),
+		language.German:    TempFile(`{"CacheMessages.Animal": "Katze"}`),
+		language.Spanish:   TempFile(`{"CacheMessages.Animal": "gato"}`),
+	}
+
+	factory := New()
+	factory.SetLocales(files, "json")
+	m := factory.Init(&CacheMessages{}).(*CacheMessages)
+
+	factory.LoadLocale(language.Bulgarian)
+	testMessage(t, m.Animal, "котка")
+
+	writeFile(files[language.Bulgarian], `{"CacheMessages.Animal": "коте"}`)
+
+	factory.LoadLocale(language.Spanish)
+	factory.LoadLocale(language.Bulgarian)
+	testMessage(t, m.Animal, "котка")
+
+	factory.Invalidate(language.Bulgarian)
+
+	factory.LoadLocale(language.Bulgarian)
+	testMessage(t, m.Animal, "коте")
+}
+
+func TestPreload(t *testing.T) {
+	files := map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"CacheMessages.Animal": "котка"}`),
+		language.German:    TempFile(`{"CacheMessages.Animal": "Katze"}`),
+		language.Spanish:   TempFile(`{"CacheMessages.Animal": "gato"}`),
+	}
+
+	factory := New()
+	factory.SetLocales(files, "json")
+	m := factory.Init(&CacheMessages{}).(*CacheMessages)
+
+	factory.Preload()
+
+	for _, path := range files {
+		os.Remove(path)
+	}
+
+	factory.LoadLocale(language.German)
+	testMessage(t, m.Animal, "Katze")
+
+	factory.LoadLocale(language.Spanish)
+	testMessage(t, m.Animal, "gato")
+}
+
+func TestSetCacheSize(t *testing.T) {
+	files := map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"CacheMessages.Animal": "котка"}`),
+		language.German:    TempFile(`{"CacheMessages.Animal": "Katze"}`),
+		language.Spanish:   TempFile(`{"CacheMessages.Animal": "gato"}`),
+	}
+
+	factory := New()
+	factory.SetLocales(files, "json")
+	factory.SetCacheSize(2)
+	m := factory.Init(&CacheMessages{}).(*CacheMessages)
+
+	factory.LoadLocale(language.Bulgarian)
+	factory.LoadLocale(language.German)
+	factory.LoadLocale(language.Bulgarian)
+	factory.LoadLocale(language.Spanish)
+
+	writeFile(files[language.Bulgarian], `{"CacheMessages.Animal": "коте"}`)
+	writeFile(files[language.German], `{"CacheMessages.Animal": "Kätzchen"}`)
+
+	factory.LoadLocale(language.Bulgarian)
+	testMessage(t, m.Animal, "котка")
+
+	factory.LoadLocale(language.German)
+	testMessage(t, m.Animal, "Kätzchen")
+}
+
+func TestSetCacheSizeNegative(t *testing.T) {
+	defer MustPanic(t, "Wrong dictionary cache size -1. Expected 0 or more.")
+
+	New().SetCacheSize(-1)
+}
@@ -0,0 +1,78 @@
+// Package example declares message structs whose initializers are generated
+// by g11n-gen, and checks that the generated messages are formatted like the
+// messages of MessageFactory.Init.
+package example
+
+//go:generate go run github.com/sgatev/g11n/cmd/g11n-gen -type Messages -locale bg=locales/bg.json
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/sgatev/g11n"
+)
+
+type PluralFormat int
+
+func (pf PluralFormat) G11nParam() string {
+	return "many"
+}
+
+type Weekday int
+
+func (wd Weekday) G11nParamLocale(tag language.Tag) string {
+	return tag.String()
+}
+
+type Code string
+
+func (c Code) G11nParam() (string, error) {
+	if c == "" {
+		return "", errors.New("empty code")
+	}
+
+	return string(c), nil
+}
+
+type Shout string
+
+func (s Shout) G11nResult(formattedMessage string) string {
+	return strings.ToUpper(formattedMessage)
+}
+
+type Logged string
+
+func (l Logged) G11nResultWithInfo(formattedMessage string, info g11n.MessageInfo) string {
+	return info.Key + ": " + formattedMessage
+}
+
+type N struct {
+	Embedded func() string                        `default:"Embedded"`
+	Greet    func(context.Context, string) string `default:"Hi %v!"`
+}
+
+type Messages struct {
+	*N
+
+	Title   string                               `default:"Title"`
+	Heading Shout                                `default:"Heading"`
+	Hello   func(string) string                  `default:"Hi %v!"`
+	Count   func(PluralFormat) string            `default:"Count: %v"`
+	Day     func(Weekday) string                 `default:"Day: %v"`
+	Total   func(float64) string                 `default:"Total: %v"`
+	Any     func(interface{}) string             `default:"Any: %v"`
+	Since   func(time.Time) string               `default:"Since %v"`
+	Percent func() string                        `default:"100%%"`
+	Names   func(...string) string               `default:"%v and %v"`
+	Owner   func(string, ...int) string          `default:"%v owns %v"`
+	Strict  func(string) (string, error)         `default:"Bye %v!"`
+	Check   func(Code) (string, error)           `default:"Code %v"`
+	Failed  func(string) error                   `default:"%v failed"`
+	Loud    func(string) Shout                   `default:"Hey %v"`
+	Log     func(...interface{}) Logged          `default:"%v"`
+	Visit   func(context.Context, ...int) string `default:"Visits: %v"`
+}
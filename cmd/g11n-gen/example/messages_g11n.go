@@ -0,0 +1,302 @@
+// Code generated by g11n-gen. DO NOT EDIT.
+
+package example
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/sgatev/g11n"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+	"sync"
+	"time"
+)
+
+// g11nTagBg is the bg locale of the precompiled messages.
+var g11nTagBg = language.MustParse("bg")
+
+// g11nPrinterBg formats the numbers of the bg messages.
+var g11nPrinterBg = message.NewPrinter(g11nTagBg, message.Catalog(catalog.NewBuilder()))
+
+// messagesLocales lists the locales of the precompiled Messages messages.
+var messagesLocales = []language.Tag{
+	language.Und,
+	g11nTagBg,
+}
+
+// messagesInitializers holds the initializers of messagesLocales.
+var messagesInitializers []func(*Messages)
+
+func init() {
+	messagesInitializers = []func(*Messages){
+		initMessages,
+		initMessagesBg,
+	}
+}
+
+// messagesMatcher matches requested locales to messagesLocales.
+var messagesMatcher = language.NewMatcher(messagesLocales)
+
+// InitMessages initializes the message fields of a Messages pointer with the
+// messages of the locale that best matches tag.
+func InitMessages(m *Messages, tag language.Tag) *Messages {
+	_, index, confidence := messagesMatcher.Match(tag)
+	if confidence == language.No {
+		index = 0
+	}
+
+	messagesInitializers[index](m)
+
+	return m
+}
+
+// initMessages initializes Messages with the default messages.
+func initMessages(m *Messages) {
+	m.N = &N{}
+	m.N.Embedded = func() string {
+		return "Embedded"
+	}
+	m.N.Greet = func(p0 context.Context, p1 string) string {
+		if other := messagesForContext(p0, 0); other != nil {
+			return other.N.Greet(p0, p1)
+		}
+
+		return fmt.Sprintf("Hi %v!", p1)
+	}
+	m.Title = "Title"
+	m.Heading = Shout(Shout("").G11nResult("Heading"))
+	m.Hello = func(p0 string) string {
+		return fmt.Sprintf("Hi %v!", p0)
+	}
+	m.Count = func(p0 PluralFormat) string {
+		return fmt.Sprintf("Count: %v", p0.G11nParam())
+	}
+	m.Day = func(p0 Weekday) string {
+		return fmt.Sprintf("Day: %v", p0.G11nParamLocale(language.Und))
+	}
+	m.Total = func(p0 float64) string {
+		return fmt.Sprintf("Total: %v", p0)
+	}
+	m.Any = func(p0 interface{}) string {
+		var err error
+		return fmt.Sprintf("Any: %v", g11nParam(p0, language.Und, &err))
+	}
+	m.Since = func(p0 time.Time) string {
+		var err error
+		return fmt.Sprintf("Since %v", g11nParam(p0, language.Und, &err))
+	}
+	m.Percent = func() string {
+		return "100%"
+	}
+	m.Names = func(p0 ...string) string {
+		args := make([]interface{}, 0, 0+len(p0))
+		for _, param := range p0 {
+			args = append(args, param)
+		}
+
+		return fmt.Sprintf("%v and %v", args...)
+	}
+	m.Owner = func(p0 string, p1 ...int) string {
+		args := make([]interface{}, 0, 1+len(p1))
+		args = append(args, p0)
+		for _, param := range p1 {
+			args = append(args, param)
+		}
+
+		return fmt.Sprintf("%v owns %v", args...)
+	}
+	m.Strict = func(p0 string) (string, error) {
+		return fmt.Sprintf("Bye %v!", p0), nil
+	}
+	m.Check = func(p0 Code) (string, error) {
+		var err error
+		result := fmt.Sprintf("Code %v", g11nParam(p0, language.Und, &err))
+		if err != nil {
+			return result, &g11n.FormatError{Key: "Messages.Check", Locale: language.Und, Err: err}
+		}
+
+		return result, nil
+	}
+	m.Failed = func(p0 string) error {
+		return errors.New(fmt.Sprintf("%v failed", p0))
+	}
+	m.Loud = func(p0 string) Shout {
+		return Shout(Shout("").G11nResult(fmt.Sprintf("Hey %v", p0)))
+	}
+	m.Log = func(p0 ...interface{}) Logged {
+		var err error
+		args := make([]interface{}, 0, 0+len(p0))
+		for _, param := range p0 {
+			args = append(args, g11nParam(param, language.Und, &err))
+		}
+
+		values := []interface{}{}
+		for _, param := range p0 {
+			values = append(values, param)
+		}
+
+		return Logged(Logged("").G11nResultWithInfo(fmt.Sprintf("%v", args...), g11n.MessageInfo{Tag: language.Und, Key: "Messages.Log", Args: values}))
+	}
+	m.Visit = func(p0 context.Context, p1 ...int) string {
+		if other := messagesForContext(p0, 0); other != nil {
+			return other.Visit(p0, p1...)
+		}
+
+		args := make([]interface{}, 0, 0+len(p1))
+		for _, param := range p1 {
+			args = append(args, param)
+		}
+
+		return fmt.Sprintf("Visits: %v", args...)
+	}
+}
+
+// initMessagesBg initializes Messages with the bg messages.
+func initMessagesBg(m *Messages) {
+	m.N = &N{}
+	m.N.Embedded = func() string {
+		return "Вграден"
+	}
+	m.N.Greet = func(p0 context.Context, p1 string) string {
+		if other := messagesForContext(p0, 1); other != nil {
+			return other.N.Greet(p0, p1)
+		}
+
+		return g11nPrinterBg.Sprintf("Здравей %v!", p1)
+	}
+	m.Title = "Заглавие"
+	m.Heading = Shout(Shout("").G11nResult("Heading"))
+	m.Hello = func(p0 string) string {
+		return g11nPrinterBg.Sprintf("Здравей %v!", p0)
+	}
+	m.Count = func(p0 PluralFormat) string {
+		return g11nPrinterBg.Sprintf("Count: %v", p0.G11nParam())
+	}
+	m.Day = func(p0 Weekday) string {
+		return g11nPrinterBg.Sprintf("Day: %v", p0.G11nParamLocale(g11nTagBg))
+	}
+	m.Total = func(p0 float64) string {
+		return g11nPrinterBg.Sprintf("Общо: %v", p0)
+	}
+	m.Any = func(p0 interface{}) string {
+		var err error
+		return g11nPrinterBg.Sprintf("Any: %v", g11nParam(p0, g11nTagBg, &err))
+	}
+	m.Since = func(p0 time.Time) string {
+		var err error
+		return g11nPrinterBg.Sprintf("Since %v", g11nParam(p0, g11nTagBg, &err))
+	}
+	m.Percent = func() string {
+		return "100%"
+	}
+	m.Names = func(p0 ...string) string {
+		args := make([]interface{}, 0, 0+len(p0))
+		for _, param := range p0 {
+			args = append(args, param)
+		}
+
+		return g11nPrinterBg.Sprintf("%v and %v", args...)
+	}
+	m.Owner = func(p0 string, p1 ...int) string {
+		args := make([]interface{}, 0, 1+len(p1))
+		args = append(args, p0)
+		for _, param := range p1 {
+			args = append(args, param)
+		}
+
+		return g11nPrinterBg.Sprintf("%[2]v принадлежи на %[1]v", args...)
+	}
+	m.Strict = func(p0 string) (string, error) {
+		return g11nPrinterBg.Sprintf("Чао %v!", p0), nil
+	}
+	m.Check = func(p0 Code) (string, error) {
+		var err error
+		result := g11nPrinterBg.Sprintf("Code %v", g11nParam(p0, g11nTagBg, &err))
+		if err != nil {
+			return result, &g11n.FormatError{Key: "Messages.Check", Locale: g11nTagBg, Err: err}
+		}
+
+		return result, nil
+	}
+	m.Failed = func(p0 string) error {
+		return errors.New(g11nPrinterBg.Sprintf("%v се провали", p0))
+	}
+	m.Loud = func(p0 string) Shout {
+		return Shout(Shout("").G11nResult(g11nPrinterBg.Sprintf("Хей %v", p0)))
+	}
+	m.Log = func(p0 ...interface{}) Logged {
+		var err error
+		args := make([]interface{}, 0, 0+len(p0))
+		for _, param := range p0 {
+			args = append(args, g11nParam(param, g11nTagBg, &err))
+		}
+
+		values := []interface{}{}
+		for _, param := range p0 {
+			values = append(values, param)
+		}
+
+		return Logged(Logged("").G11nResultWithInfo(g11nPrinterBg.Sprintf("%v", args...), g11n.MessageInfo{Tag: g11nTagBg, Key: "Messages.Log", Args: values}))
+	}
+	m.Visit = func(p0 context.Context, p1 ...int) string {
+		if other := messagesForContext(p0, 1); other != nil {
+			return other.Visit(p0, p1...)
+		}
+
+		args := make([]interface{}, 0, 0+len(p1))
+		for _, param := range p1 {
+			args = append(args, param)
+		}
+
+		return g11nPrinterBg.Sprintf("Visits: %v", args...)
+	}
+}
+
+// messagesContexts holds the Messages of each locale that context-aware messages
+// switch to. They are initialized on first use.
+var messagesContexts [2]struct {
+	once     sync.Once
+	messages Messages
+}
+
+// messagesForContext returns the Messages of the locale carried by a context.
+// The result is nil if the context carries no precompiled locale or it
+// carries the locale of the current messages.
+func messagesForContext(ctx context.Context, current int) *Messages {
+	if ctx == nil {
+		return nil
+	}
+
+	tag, ok := g11n.LocaleFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	for index := 1; index < len(messagesLocales); index++ {
+		if messagesLocales[index] != tag || index == current {
+			continue
+		}
+
+		locale := &messagesContexts[index]
+		locale.once.Do(func() {
+			messagesInitializers[index](&locale.messages)
+		})
+
+		return &locale.messages
+	}
+
+	return nil
+}
+
+// g11nParam formats a message parameter for a locale and records the first
+// error of a parameter formatter.
+func g11nParam(param interface{}, tag language.Tag, err *error) interface{} {
+	formatted, paramErr := g11n.FormatParam(param, tag)
+	if paramErr != nil && *err == nil {
+		*err = paramErr
+	}
+
+	return formatted
+}
@@ -0,0 +1,71 @@
+package example_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	"github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/cmd/g11n-gen/example"
+)
+
+// render formats every message of a struct with the same arguments. Errors
+// are rendered as their text.
+func render(m *Messages) []string {
+	ctx := g11n.WithLocale(context.Background(), language.Bulgarian)
+	since := time.Date(2015, time.March, 7, 16, 5, 9, 0, time.UTC)
+
+	strict, strictErr := m.Strict("Ana")
+	check, checkErr := m.Check("")
+
+	return []string{
+		m.Embedded(),
+		m.Greet(context.Background(), "Ana"),
+		m.Greet(ctx, "Ana"),
+		m.Title,
+		string(m.Heading),
+		m.Hello("Ana"),
+		m.Count(3),
+		m.Day(Weekday(1)),
+		m.Total(1234.5),
+		m.Any(g11n.Percent(0.25)),
+		m.Since(since),
+		m.Percent(),
+		m.Names("Ana", "Bob"),
+		m.Owner("Ana", 1234),
+		strict, fmt.Sprint(strictErr),
+		check, fmt.Sprint(checkErr),
+		m.Failed("Ana").Error(),
+		string(m.Loud("Ana")),
+		string(m.Log("Ana")),
+		m.Visit(context.Background(), 3),
+		m.Visit(ctx, 3),
+	}
+}
+
+func TestGeneratedMessages(t *testing.T) {
+	for _, tag := range []language.Tag{language.Und, language.Bulgarian} {
+		factory := g11n.New()
+		factory.SetLocale(language.Bulgarian, "json", "locales/bg.json")
+
+		m := factory.Init(&Messages{}).(*Messages)
+		if tag != language.Und {
+			factory.LoadLocale(tag)
+		}
+
+		expected := render(m)
+		actual := render(InitMessages(&Messages{}, tag))
+
+		for i := range expected {
+			if actual[i] != expected[i] {
+				t.Errorf("Generated message is not the same as in MessageFactory.Init.\n"+
+					"\tLocale: %v\n"+
+					"\tActual: %v\n"+
+					"\tExpected: %v\n", tag, actual[i], expected[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,656 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	pathpkg "path"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/language"
+
+	"github.com/sgatev/g11n/internal/scan"
+	"github.com/sgatev/g11n/pattern"
+)
+
+// Formatter method names recognized by g11n.
+const (
+	paramFormatterMethod       = "G11nParam"
+	paramLocaleFormatterMethod = "G11nParamLocale"
+	resultFormatterMethod      = "G11nResult"
+	resultInfoFormatterMethod  = "G11nResultWithInfo"
+	paramEscaperMethod         = "G11nEscape"
+)
+
+// Packages referenced by generated code.
+const (
+	g11nPackage    = "github.com/sgatev/g11n"
+	messagePackage = "golang.org/x/text/message"
+	catalogPackage = "golang.org/x/text/message/catalog"
+)
+
+// defaultLocaleName names the default messages in generation errors.
+const defaultLocaleName = "default"
+
+// errorType is the predeclared error type.
+var errorType = types.Universe.Lookup("error").Type()
+
+// localeDictionary holds the translated messages of a single locale.
+type localeDictionary struct {
+	tag        language.Tag
+	dictionary map[string]string
+}
+
+// initializer describes the initializer func of a message struct that is
+// being generated for a locale.
+type initializer struct {
+
+	// structName is the name of the message struct with a lowered first
+	// letter, which prefixes the declarations of the struct.
+	structName string
+
+	// index is the index of the locale in the locales of the struct.
+	index int
+
+	// locale names the locale in generation errors.
+	locale string
+
+	// tag and printer are the expressions of the locale and of its number
+	// printer. The printer is empty for the default messages, which are
+	// formatted by package fmt.
+	tag, printer string
+
+	dictionary map[string]string
+}
+
+// generator emits reflection-free initializers for g11n message structs.
+type generator struct {
+	buf     bytes.Buffer
+	pkg     *types.Package
+	locales []localeDictionary
+	imports map[string]string
+
+	// needsParamHelper is set when a parameter has to be formatted by
+	// g11n.FormatParam.
+	needsParamHelper bool
+}
+
+// newGenerator creates a generator for the message structs of a package.
+func newGenerator(pkg *types.Package, locales []localeDictionary) *generator {
+	return &generator{
+		pkg:     pkg,
+		locales: locales,
+		imports: map[string]string{
+			"golang.org/x/text/language": "language",
+		},
+	}
+}
+
+// printf writes formatted generated code.
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+// qualifier names the packages of the types used in generated code and
+// records them as imports.
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+
+	return g.use(pkg.Path(), pkg.Name())
+}
+
+// use records an import of generated code and returns its package name.
+func (g *generator) use(path, name string) string {
+	g.imports[path] = name
+
+	return name
+}
+
+// typeString returns the representation of a type in generated code.
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, g.qualifier)
+}
+
+// errorTypeString returns the representation of a type in generation errors.
+func (g *generator) errorTypeString(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == g.pkg {
+			return ""
+		}
+
+		return pkg.Name()
+	})
+}
+
+// generate emits the initializers of a list of message structs and returns
+// the formatted source file. An error is returned if a message could not be
+// precompiled the way MessageFactory.Init formats it.
+func (g *generator) generate(structs []*scan.Struct) ([]byte, error) {
+	for _, locale := range g.locales {
+		g.printf("// %v is the %v locale of the precompiled messages.\n", tagName(locale.tag), locale.tag)
+		g.printf("var %v = language.MustParse(%q)\n\n", tagName(locale.tag), locale.tag.String())
+
+		g.printf("// %v formats the numbers of the %v messages.\n", printerName(locale.tag), locale.tag)
+		g.printf("var %v = %v.NewPrinter(%v, %v.Catalog(%v.NewBuilder()))\n\n",
+			printerName(locale.tag), g.use(messagePackage, "message"), tagName(locale.tag),
+			g.use(messagePackage, "message"), g.use(catalogPackage, "catalog"))
+	}
+
+	for _, s := range structs {
+		if err := g.generateStruct(s); err != nil {
+			return nil, err
+		}
+	}
+
+	body := g.buf.Bytes()
+	g.buf = bytes.Buffer{}
+
+	g.printf("// Code generated by g11n-gen. DO NOT EDIT.\n\n")
+	g.printf("package %v\n\n", g.pkg.Name())
+
+	paths := make([]string, 0, len(g.imports))
+	for path := range g.imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	g.printf("import (\n")
+	for _, path := range paths {
+		if name := g.imports[path]; name != pathpkg.Base(path) {
+			g.printf("%v %q\n", name, path)
+		} else {
+			g.printf("%q\n", path)
+		}
+	}
+	g.printf(")\n\n")
+
+	g.buf.Write(body)
+
+	if g.needsParamHelper {
+		g.printf(`
+// g11nParam formats a message parameter for a locale and records the first
+// error of a parameter formatter.
+func g11nParam(param interface{}, tag language.Tag, err *error) interface{} {
+	formatted, paramErr := g11n.FormatParam(param, tag)
+	if paramErr != nil && *err == nil {
+		*err = paramErr
+	}
+
+	return formatted
+}
+`)
+	}
+
+	return format.Source(g.buf.Bytes())
+}
+
+// generateStruct emits the initializers of a single message struct.
+func (g *generator) generateStruct(s *scan.Struct) error {
+	name := s.Name()
+	structName := lowerFirst(name)
+	initName := "init" + name
+
+	g.printf("// %vLocales lists the locales of the precompiled %v messages.\n", structName, name)
+	g.printf("var %vLocales = []language.Tag{\n", structName)
+	g.printf("language.Und,\n")
+	for _, locale := range g.locales {
+		g.printf("%v,\n", tagName(locale.tag))
+	}
+	g.printf("}\n\n")
+
+	// The initializers of structs with context-aware messages refer to
+	// themselves through the context helper, so they are assigned in an
+	// init func to avoid an initialization cycle.
+	withContext := hasContextFuncs(s)
+
+	g.printf("// %vInitializers holds the initializers of %vLocales.\n", structName, structName)
+	if withContext {
+		g.printf("var %vInitializers []func(*%v)\n\n", structName, name)
+		g.printf("func init() {\n%vInitializers = []func(*%v){\n", structName, name)
+	} else {
+		g.printf("var %vInitializers = []func(*%v){\n", structName, name)
+	}
+	g.printf("%v,\n", initName)
+	for _, locale := range g.locales {
+		g.printf("%v%v,\n", initName, tagSuffix(locale.tag))
+	}
+	if withContext {
+		g.printf("}\n")
+	}
+	g.printf("}\n\n")
+
+	g.printf("// %vMatcher matches requested locales to %vLocales.\n", structName, structName)
+	g.printf("var %vMatcher = language.NewMatcher(%vLocales)\n\n", structName, structName)
+
+	g.printf("// Init%v initializes the message fields of a %v pointer with the\n", name, name)
+	g.printf("// messages of the locale that best matches tag.\n")
+	g.printf("func Init%v(m *%v, tag language.Tag) *%v {\n", name, name, name)
+	g.printf("_, index, confidence := %vMatcher.Match(tag)\n", structName)
+	g.printf("if confidence == language.No {\nindex = 0\n}\n\n")
+	g.printf("%vInitializers[index](m)\n\n", structName)
+	g.printf("return m\n}\n\n")
+
+	g.printf("// %v initializes %v with the default messages.\n", initName, name)
+	g.printf("func %v(m *%v) {\n", initName, name)
+	err := g.generateFields("", s, &initializer{
+		structName: structName,
+		locale:     defaultLocaleName,
+		tag:        "language.Und",
+	})
+	if err != nil {
+		return err
+	}
+	g.printf("}\n\n")
+
+	for i, locale := range g.locales {
+		g.printf("// %v%v initializes %v with the %v messages.\n", initName, tagSuffix(locale.tag), name, locale.tag)
+		g.printf("func %v%v(m *%v) {\n", initName, tagSuffix(locale.tag), name)
+		err := g.generateFields("", s, &initializer{
+			structName: structName,
+			index:      i + 1,
+			locale:     locale.tag.String(),
+			tag:        tagName(locale.tag),
+			printer:    printerName(locale.tag),
+			dictionary: locale.dictionary,
+		})
+		if err != nil {
+			return err
+		}
+		g.printf("}\n\n")
+	}
+
+	if withContext {
+		g.generateContextHelper(name, structName)
+	}
+
+	return nil
+}
+
+// generateContextHelper emits the lookup of the messages that context-aware
+// message funcs of a struct switch to.
+func (g *generator) generateContextHelper(name, structName string) {
+	g.use("context", "context")
+	g.use("sync", "sync")
+
+	g.printf("// %vContexts holds the %v of each locale that context-aware messages\n", structName, name)
+	g.printf("// switch to. They are initialized on first use.\n")
+	g.printf("var %vContexts [%v]struct {\nonce sync.Once\nmessages %v\n}\n\n", structName, len(g.locales)+1, name)
+
+	g.printf("// %vForContext returns the %v of the locale carried by a context.\n", structName, name)
+	g.printf("// The result is nil if the context carries no precompiled locale or it\n")
+	g.printf("// carries the locale of the current messages.\n")
+	g.printf("func %vForContext(ctx context.Context, current int) *%v {\n", structName, name)
+	g.printf("if ctx == nil {\nreturn nil\n}\n\n")
+	g.printf("tag, ok := %v.LocaleFromContext(ctx)\n", g.use(g11nPackage, "g11n"))
+	g.printf("if !ok {\nreturn nil\n}\n\n")
+	g.printf("for index := 1; index < len(%vLocales); index++ {\n", structName)
+	g.printf("if %vLocales[index] != tag || index == current {\ncontinue\n}\n\n", structName)
+	g.printf("locale := &%vContexts[index]\n", structName)
+	g.printf("locale.once.Do(func() {\n%vInitializers[index](&locale.messages)\n})\n\n", structName)
+	g.printf("return &locale.messages\n}\n\n")
+	g.printf("return nil\n}\n\n")
+}
+
+// generateFields emits the assignments of the message fields of a struct
+// reachable through a path of embedded fields of the initialized struct.
+func (g *generator) generateFields(path string, s *scan.Struct, init *initializer) error {
+	for _, field := range s.Fields {
+		target := "m." + path + field.Name()
+
+		switch field.Kind {
+		case scan.EmbeddedField:
+			g.printf("%v = &%v{}\n", target, g.typeString(field.Embedded.Named))
+			if err := g.generateFields(path+field.Name()+".", field.Embedded, init); err != nil {
+				return err
+			}
+
+		case scan.StringField:
+			g.generateString(target, field, init)
+
+		case scan.FuncField:
+			if err := g.generateFunc(target, path, field, init); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf(unsupportedFieldMessage, field.Key, g.errorTypeString(field.Var.Type()))
+		}
+	}
+
+	return nil
+}
+
+// lookup returns the pattern of a message in the locale of an initializer.
+func (init *initializer) lookup(field *scan.Field) string {
+	if message, ok := init.dictionary[field.Key]; ok {
+		return message
+	}
+
+	return field.Default
+}
+
+// generateString emits the assignment of a message string.
+func (g *generator) generateString(target string, field *scan.Field, init *initializer) {
+	message := strconv.Quote(init.lookup(field))
+	fieldType := field.Var.Type()
+
+	switch {
+	case hasMethod(fieldType, resultInfoFormatterMethod):
+		g.printf("%v = %v\n", target, g.formatResult(fieldType, message, field.Key, "[]interface{}{}", init))
+	case hasMethod(fieldType, resultFormatterMethod):
+		g.printf("%v = %v\n", target, g.formatResult(fieldType, message, field.Key, "", init))
+	default:
+		g.printf("%v = %v\n", target, message)
+	}
+}
+
+// generateFunc emits the assignment of a message func with a precompiled
+// pattern. Message funcs that could not be precompiled are reported as errors.
+func (g *generator) generateFunc(target, path string, field *scan.Field, init *initializer) error {
+	signature := field.Signature
+	params := signature.Params()
+	results := signature.Results()
+	messagePattern := init.lookup(field)
+
+	if results.Len() != 1 && !field.ReturnsError() {
+		return fmt.Errorf(wrongResultsMessage, field.Key, g.errorTypeString(results))
+	}
+
+	resultType := results.At(0).Type()
+	isError := types.Identical(resultType, errorType)
+	switch {
+	case isEscaped(resultType):
+		return fmt.Errorf(escapedResultMessage, field.Key, g.errorTypeString(resultType))
+	case !isStringKind(resultType) && !isError:
+		return fmt.Errorf(unsupportedResultMessage, field.Key, g.errorTypeString(resultType))
+	case len(pattern.ParsePlaceholders(messagePattern)) > 0:
+		return fmt.Errorf(placeholdersMessage, init.locale, field.Key)
+	}
+
+	messageParams := len(field.Params())
+	if !signature.Variadic() {
+		if count := pattern.Count(messagePattern); count > messageParams {
+			return fmt.Errorf(missingArgsMessage, init.locale, field.Key, count, messageParams)
+		}
+
+		// Unused arguments would be formatted as %!(EXTRA ...) and fail go vet.
+		if arg, ok := unusedArg(messagePattern, messageParams); ok {
+			return fmt.Errorf(unusedArgMessage, init.locale, field.Key, arg+1)
+		}
+	}
+
+	var declarations, callArgs, args, values []string
+	var variadic string
+	var variadicParam types.Type
+	withContext := params.Len() > 0 && scan.IsContext(params.At(0).Type())
+	usesErr := false
+
+	for i := 0; i < params.Len(); i++ {
+		name := "p" + strconv.Itoa(i)
+		paramType := params.At(i).Type()
+
+		if signature.Variadic() && i == params.Len()-1 {
+			variadic = name
+			variadicParam = paramType.(*types.Slice).Elem()
+			declarations = append(declarations, name+" ..."+g.typeString(variadicParam))
+			callArgs = append(callArgs, name+"...")
+			continue
+		}
+
+		declarations = append(declarations, name+" "+g.typeString(paramType))
+		callArgs = append(callArgs, name)
+
+		if i == 0 && withContext {
+			continue
+		}
+
+		arg, paramUsesErr := g.paramExpression(name, paramType, init.tag)
+		args = append(args, arg)
+		values = append(values, name)
+		usesErr = usesErr || paramUsesErr
+	}
+
+	result := g.typeString(resultType)
+	resultTypes := result
+	if field.ReturnsError() {
+		resultTypes = "(" + result + ", error)"
+	}
+
+	g.printf("%v = func(%v) %v {\n", target, strings.Join(declarations, ", "), resultTypes)
+
+	// The messages of the locale carried by a context are formatted by the
+	// initialized messages of that locale.
+	if withContext {
+		g.printf("if other := %vForContext(p0, %v); other != nil {\n", init.structName, init.index)
+		g.printf("return other.%v%v(%v)\n}\n\n", path, field.Name(), strings.Join(callArgs, ", "))
+	}
+
+	var variadicArg string
+	if variadic != "" {
+		var paramUsesErr bool
+		variadicArg, paramUsesErr = g.paramExpression("param", variadicParam, init.tag)
+		usesErr = usesErr || paramUsesErr
+	}
+
+	if usesErr {
+		g.printf("var err error\n")
+	}
+
+	sprintf := init.printer + ".Sprintf"
+	if init.printer == "" {
+		sprintf = g.use("fmt", "fmt") + ".Sprintf"
+	}
+
+	var message string
+	switch {
+	case variadic != "":
+		g.printf("args := make([]interface{}, 0, %v+len(%v))\n", len(args), variadic)
+		if len(args) > 0 {
+			g.printf("args = append(args, %v)\n", strings.Join(args, ", "))
+		}
+		g.printf("for _, param := range %v {\nargs = append(args, %v)\n}\n\n", variadic, variadicArg)
+		message = fmt.Sprintf("%v(%q, args...)", sprintf, messagePattern)
+	case len(args) == 0:
+		var noParams []interface{}
+		message = strconv.Quote(fmt.Sprintf(messagePattern, noParams...))
+	default:
+		message = fmt.Sprintf("%v(%q, %v)", sprintf, messagePattern, strings.Join(args, ", "))
+	}
+
+	// Result formatters with info receive the arguments as passed.
+	valuesExpression := "[]interface{}{" + strings.Join(values, ", ") + "}"
+	if hasMethod(resultType, resultInfoFormatterMethod) && variadic != "" {
+		g.printf("values := %v\n", valuesExpression)
+		g.printf("for _, param := range %v {\nvalues = append(values, param)\n}\n\n", variadic)
+		valuesExpression = "values"
+	}
+
+	var resultExpression string
+	switch {
+	case isError:
+		// Generated messages have a fixed locale, so errors are plain.
+		resultExpression = g.use("errors", "errors") + ".New(" + message + ")"
+	case hasMethod(resultType, resultInfoFormatterMethod):
+		resultExpression = g.formatResult(resultType, message, field.Key, valuesExpression, init)
+	case hasMethod(resultType, resultFormatterMethod):
+		resultExpression = g.formatResult(resultType, message, field.Key, "", init)
+	case types.Identical(resultType, types.Typ[types.String]):
+		resultExpression = message
+	default:
+		resultExpression = result + "(" + message + ")"
+	}
+
+	switch {
+	case !field.ReturnsError():
+		g.printf("return %v\n", resultExpression)
+	case !usesErr:
+		g.printf("return %v, nil\n", resultExpression)
+	default:
+		g.printf("result := %v\n", resultExpression)
+		g.printf("if err != nil {\n")
+		g.printf("return result, &%v.FormatError{Key: %q, Locale: %v, Err: err}\n}\n\n",
+			g.use(g11nPackage, "g11n"), field.Key, init.tag)
+		g.printf("return result, nil\n")
+	}
+	g.printf("}\n")
+
+	return nil
+}
+
+// formatResult returns the expression that converts a message to a result
+// type with a result formatter. Result formatters with info receive the
+// expression of the message arguments, while plain ones receive none.
+func (g *generator) formatResult(resultType types.Type, message, messageKey, values string, init *initializer) string {
+	result := g.typeString(resultType)
+
+	if values == "" {
+		return fmt.Sprintf("%v(%v(\"\").%v(%v))", result, result, resultFormatterMethod, message)
+	}
+
+	return fmt.Sprintf("%v(%v(\"\").%v(%v, %v.MessageInfo{Tag: %v, Key: %q, Args: %v}))",
+		result, result, resultInfoFormatterMethod, message, g.use(g11nPackage, "g11n"), init.tag, messageKey, values)
+}
+
+// paramExpression returns the expression that formats a message parameter
+// for a locale, and whether the expression records formatting errors in err.
+func (g *generator) paramExpression(name string, paramType types.Type, tag string) (string, bool) {
+	// Parameters of predeclared types are formatted as they are.
+	if _, ok := paramType.(*types.Basic); ok {
+		return name, false
+	}
+
+	// The types of g11n and interfaces are formatted as at runtime.
+	named, isNamed := paramType.(*types.Named)
+	isG11nType := isNamed && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == g11nPackage
+	if !isG11nType && !types.IsInterface(paramType) {
+		switch {
+		case hasMethod(paramType, paramLocaleFormatterMethod):
+			return name + "." + paramLocaleFormatterMethod + "(" + tag + ")", false
+		case hasMethod(paramType, paramFormatterMethod) && !returnsError(paramType, paramFormatterMethod):
+			return name + "." + paramFormatterMethod + "()", false
+		case !hasMethod(paramType, paramFormatterMethod) && !isNamed:
+			return name, false
+		}
+	}
+
+	g.needsParamHelper = true
+	g.use(g11nPackage, "g11n")
+
+	return "g11nParam(" + name + ", " + tag + ", &err)", true
+}
+
+// unusedArg returns the first of the arguments of a message func that its
+// pattern does not use, if any.
+func unusedArg(messagePattern string, messageParams int) (int, bool) {
+	used := pattern.Args(messagePattern)
+	for arg := 0; arg < messageParams; arg++ {
+		if arg >= len(used) || used[arg] != arg {
+			return arg, true
+		}
+	}
+
+	return 0, false
+}
+
+// hasContextFuncs reports whether a message struct or any struct embedded
+// in it has message funcs whose first parameter is a context.Context.
+func hasContextFuncs(s *scan.Struct) bool {
+	for _, field := range s.Fields {
+		switch field.Kind {
+		case scan.EmbeddedField:
+			if hasContextFuncs(field.Embedded) {
+				return true
+			}
+		case scan.FuncField:
+			if params := field.Signature.Params(); params.Len() > 0 && scan.IsContext(params.At(0).Type()) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// hasMethod reports whether the method set of a type has a method.
+func hasMethod(t types.Type, name string) bool {
+	return lookupMethod(t, name) != nil
+}
+
+// lookupMethod returns a method in the method set of a type, if any.
+func lookupMethod(t types.Type, name string) *types.Func {
+	methods := types.NewMethodSet(t)
+	for i := 0; i < methods.Len(); i++ {
+		if method := methods.At(i).Obj(); method.Name() == name {
+			return method.(*types.Func)
+		}
+	}
+
+	return nil
+}
+
+// returnsError reports whether a method of a type returns an error after
+// its result.
+func returnsError(t types.Type, name string) bool {
+	results := lookupMethod(t, name).Type().(*types.Signature).Results()
+
+	return results.Len() == 2 && types.Identical(results.At(1).Type(), errorType)
+}
+
+// isEscaped reports whether a result type escapes the parameters of its
+// messages at runtime, i.e. it is template.HTML or has a G11nEscape method.
+func isEscaped(t types.Type) bool {
+	if named, ok := t.(*types.Named); ok {
+		object := named.Obj()
+		if object.Pkg() != nil && object.Pkg().Path() == "html/template" && object.Name() == "HTML" {
+			return true
+		}
+	}
+
+	return hasMethod(t, paramEscaperMethod)
+}
+
+// isStringKind reports whether the underlying type of a type is string.
+func isStringKind(t types.Type) bool {
+	basic, ok := t.Underlying().(*types.Basic)
+
+	return ok && basic.Kind() == types.String
+}
+
+// tagSuffix converts a language tag to an identifier suffix, e.g. pt-BR to PtBR.
+func tagSuffix(tag language.Tag) string {
+	var suffix strings.Builder
+	for _, part := range strings.Split(tag.String(), "-") {
+		if part == "" {
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		suffix.WriteString(string(runes))
+	}
+
+	return suffix.String()
+}
+
+// tagName returns the name of the generated variable of a locale.
+func tagName(tag language.Tag) string {
+	return "g11nTag" + tagSuffix(tag)
+}
+
+// printerName returns the name of the generated number printer of a locale.
+func printerName(tag language.Tag) string {
+	return "g11nPrinter" + tagSuffix(tag)
+}
+
+// lowerFirst lowers the first letter of an identifier.
+func lowerFirst(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+
+	return string(runes)
+}
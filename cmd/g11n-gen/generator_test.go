@@ -0,0 +1,282 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/sgatev/g11n/internal/cli"
+	"github.com/sgatev/g11n/internal/scan"
+)
+
+// stubImporter type-checks packages from source. Imported packages are
+// replaced by the stubs in testdata/src, which declare what the generator
+// inspects, so that no package is loaded by the go command.
+type stubImporter struct {
+	fset     *token.FileSet
+	packages map[string]*types.Package
+}
+
+// Import implements types.Importer.
+func (si *stubImporter) Import(path string) (*types.Package, error) {
+	if pkg, ok := si.packages[path]; ok {
+		return pkg, nil
+	}
+
+	return si.check(path, filepath.Join("testdata", "src", filepath.FromSlash(path)))
+}
+
+// check type-checks the source files of a package in a directory, except
+// tests and generated files.
+func (si *stubImporter) check(path, dir string) (*types.Package, error) {
+	fileNames, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*ast.File
+	for _, fileName := range fileNames {
+		if strings.HasSuffix(fileName, "_test.go") || strings.HasSuffix(fileName, "_g11n.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(si.fset, fileName, nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	config := types.Config{Importer: si}
+	pkg, err := config.Check(path, si.fset, files, nil)
+	if err != nil {
+		return nil, err
+	}
+	si.packages[path] = pkg
+
+	return pkg, nil
+}
+
+// loadPackage type-checks a package of message structs against the stubs.
+func loadPackage(t *testing.T, path, dir string) *types.Package {
+	importer := &stubImporter{
+		fset:     token.NewFileSet(),
+		packages: map[string]*types.Package{},
+	}
+
+	pkg, err := importer.check(path, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pkg
+}
+
+// loadExample type-checks the example package of message structs.
+func loadExample(t *testing.T) *types.Package {
+	return loadPackage(t, "github.com/sgatev/g11n/cmd/g11n-gen/example", "example")
+}
+
+// loadUnsupported type-checks the package of unsupported message structs.
+func loadUnsupported(t *testing.T) *types.Package {
+	return loadPackage(t, "unsupported", filepath.Join("testdata", "unsupported"))
+}
+
+// generateStructs generates the initializers of message structs.
+func generateStructs(pkg *types.Package, locales []localeDictionary, names ...string) ([]byte, error) {
+	var structs []*scan.Struct
+	for _, name := range names {
+		structs = append(structs, scan.Load(pkg.Scope().Lookup(name).Type().(*types.Named)))
+	}
+
+	return newGenerator(pkg, locales).generate(structs)
+}
+
+// generateSource generates the initializers of the example messages.
+func generateSource(t *testing.T, locales []localeDictionary) string {
+	source, err := generateStructs(loadExample(t), locales, "Messages")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(source)
+}
+
+func testContains(t *testing.T, source string, expected ...string) {
+	for _, snippet := range expected {
+		if !strings.Contains(source, snippet) {
+			t.Errorf("Generated source does not contain expected code.\n"+
+				"\tExpected: %v\n"+
+				"\tSource: %v\n", snippet, source)
+		}
+	}
+}
+
+func TestGenerateExample(t *testing.T) {
+	locales, err := loadLocales([]cli.LocaleFile{{
+		Tag:    language.Bulgarian,
+		Path:   filepath.Join("example", "locales", "bg.json"),
+		Format: "json",
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := ioutil.ReadFile(filepath.Join("example", "messages_g11n.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if actual := generateSource(t, locales); actual != string(expected) {
+		t.Errorf("Generated source is not the same as example/messages_g11n.go. Run go generate.\n"+
+			"\tActual: %v\n", actual)
+	}
+}
+
+func TestGenerateDefaultMessages(t *testing.T) {
+	source := generateSource(t, nil)
+
+	testContains(t, source,
+		"// Code generated by g11n-gen. DO NOT EDIT.",
+		"package example",
+		"func InitMessages(m *Messages, tag language.Tag) *Messages {",
+		"m.N = &N{}",
+		`m.N.Embedded = func() string {
+		return "Embedded"
+	}`,
+		`m.Title = "Title"`,
+		`m.Heading = Shout(Shout("").G11nResult("Heading"))`,
+		`return fmt.Sprintf("Hi %v!", p0)`,
+		`return fmt.Sprintf("Count: %v", p0.G11nParam())`,
+		`return fmt.Sprintf("Day: %v", p0.G11nParamLocale(language.Und))`,
+		`return fmt.Sprintf("Total: %v", p0)`,
+		`return fmt.Sprintf("Any: %v", g11nParam(p0, language.Und, &err))`,
+		`return fmt.Sprintf("Since %v", g11nParam(p0, language.Und, &err))`,
+		`return "100%"`,
+		`args := make([]interface{}, 0, 0+len(p0))
+		for _, param := range p0 {
+			args = append(args, param)
+		}
+
+		return fmt.Sprintf("%v and %v", args...)`,
+		`args := make([]interface{}, 0, 1+len(p1))
+		args = append(args, p0)`,
+		`m.Strict = func(p0 string) (string, error) {
+		return fmt.Sprintf("Bye %v!", p0), nil
+	}`,
+		`result := fmt.Sprintf("Code %v", g11nParam(p0, language.Und, &err))
+		if err != nil {
+			return result, &g11n.FormatError{Key: "Messages.Check", Locale: language.Und, Err: err}
+		}`,
+		`return errors.New(fmt.Sprintf("%v failed", p0))`,
+		`return Shout(Shout("").G11nResult(fmt.Sprintf("Hey %v", p0)))`,
+		`g11n.MessageInfo{Tag: language.Und, Key: "Messages.Log", Args: values}`,
+		"func g11nParam(param interface{}, tag language.Tag, err *error) interface{} {")
+}
+
+func TestGenerateLocalizedMessages(t *testing.T) {
+	source := generateSource(t, []localeDictionary{{
+		tag: language.Bulgarian,
+		dictionary: map[string]string{
+			"Messages.Hello": "Здравей %v!",
+			"Messages.Title": "Заглавие",
+			"N.Embedded":     "Вграден",
+		},
+	}})
+
+	testContains(t, source,
+		`var g11nTagBg = language.MustParse("bg")`,
+		"var g11nPrinterBg = message.NewPrinter(g11nTagBg, message.Catalog(catalog.NewBuilder()))",
+		"func initMessagesBg(m *Messages) {",
+		`return g11nPrinterBg.Sprintf("Здравей %v!", p0)`,
+		`return g11nPrinterBg.Sprintf("Total: %v", p0)`,
+		`return g11nPrinterBg.Sprintf("Day: %v", p0.G11nParamLocale(g11nTagBg))`,
+		`m.Title = "Заглавие"`,
+		`m.Heading = Shout(Shout("").G11nResult("Heading"))`,
+		`return "Вграден"`)
+}
+
+func TestGenerateContextMessages(t *testing.T) {
+	source := generateSource(t, []localeDictionary{{
+		tag:        language.Bulgarian,
+		dictionary: map[string]string{"N.Greet": "Здравей %v!"},
+	}})
+
+	testContains(t, source,
+		`if other := messagesForContext(p0, 0); other != nil {
+			return other.N.Greet(p0, p1)
+		}`,
+		`if other := messagesForContext(p0, 1); other != nil {
+			return other.Visit(p0, p1...)
+		}`,
+		"tag, ok := g11n.LocaleFromContext(ctx)",
+		"func messagesForContext(ctx context.Context, current int) *Messages {")
+}
+
+func TestGenerateUnsupportedMessages(t *testing.T) {
+	pkg := loadUnsupported(t)
+
+	cases := []struct {
+		name    string
+		message string
+	}{
+		{"Results", "Wrong results of message Results.Results. Expected a message and an optional error, got (string, int)."},
+		{"Lazy", "Message Lazy.Lazy returns g11n.Message. g11n-gen supports results of a string kind and error."},
+		{"Localized", "Message Localized.Localized returns *g11n.Error. g11n-gen supports results of a string kind and error."},
+		{"SafeHTML", "Message SafeHTML.SafeHTML returns g11n.SafeHTML, whose parameters g11n-gen does not escape."},
+		{"HTML", "Message HTML.HTML returns template.HTML, whose parameters g11n-gen does not escape."},
+		{"Placeholders", "The default message Placeholders.Placeholders uses placeholders in braces, which g11n-gen does not support."},
+		{"MissingArgs", "The default message MissingArgs.MissingArgs uses 2 arguments, the message func has 1."},
+		{"UnusedArgs", "The default message UnusedArgs.UnusedArgs does not use argument 2 of the message func."},
+		{"Invalid", "Message field Invalid.Invalid of type int must be a string or a func."},
+	}
+
+	for _, c := range cases {
+		_, err := generateStructs(pkg, nil, c.name)
+		if err == nil || err.Error() != c.message {
+			t.Errorf("Unsupported message is not reported.\n"+
+				"\tExpected: %v\n"+
+				"\tActual: %v\n", c.message, err)
+		}
+	}
+}
+
+func TestGenerateUnsupportedTranslations(t *testing.T) {
+	pkg := loadExample(t)
+
+	cases := []struct {
+		translation string
+		message     string
+	}{
+		{"Здравей {1, select, other {%v}}!", "The bg message Messages.Hello uses placeholders in braces, which g11n-gen does not support."},
+		{"Здравей!", "The bg message Messages.Hello does not use argument 1 of the message func."},
+	}
+
+	for _, c := range cases {
+		_, err := generateStructs(pkg, []localeDictionary{{
+			tag:        language.Bulgarian,
+			dictionary: map[string]string{"Messages.Hello": c.translation},
+		}}, "Messages")
+
+		if err == nil || err.Error() != c.message {
+			t.Errorf("Unsupported translation is not reported.\n"+
+				"\tExpected: %v\n"+
+				"\tActual: %v\n", c.message, err)
+		}
+	}
+}
+
+func TestTagSuffix(t *testing.T) {
+	if actual := tagSuffix(language.BrazilianPortuguese); actual != "PtBR" {
+		t.Errorf("Tag suffix is not correct.\n"+
+			"\tExpected: PtBR\n"+
+			"\tActual: %v\n", actual)
+	}
+}
@@ -0,0 +1,145 @@
+// Command g11n-gen generates reflection-free initializers for g11n message
+// structs.
+//
+// It is meant to be invoked by go generate in the package that declares
+// the message structs:
+//
+//	//go:generate g11n-gen -type Messages -locale bg=locales/bg.json -locale es=locales/es.yaml
+//
+// For every message struct the generated file declares a function
+//
+//	func InitMessages(m *Messages, tag language.Tag) *Messages
+//
+// that fills the fields of the struct with plain Go funcs whose patterns are
+// resolved at generation time for the locale that best matches tag. The
+// message struct and the locale files stay the same as with MessageFactory.Init.
+//
+// Parameters are formatted for the locale as by MessageFactory.Init, and
+// context-aware messages switch to the locale of their context. Messages
+// that could not be precompiled are reported as errors: placeholders in
+// braces, results that escape their parameters, lazy g11n.Message and
+// *g11n.Error results, patterns that use more arguments than their func has
+// and, unless the func is variadic, patterns that leave some of its arguments
+// unused. Error results are plain errors, since the generated messages have
+// a fixed locale.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/types"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sgatev/g11n/internal/cli"
+	"github.com/sgatev/g11n/internal/scan"
+	"github.com/sgatev/g11n/locale"
+)
+
+// Error message patterns.
+const (
+	unknownFormatMessage     = "Unknown locale format '%v'."
+	unknownTypeMessage       = "Unknown message struct '%v'."
+	missingTypeFlagsMessage  = "No message structs specified with -type."
+	unsupportedFieldMessage  = "Message field %v of type %v must be a string or a func."
+	wrongResultsMessage      = "Wrong results of message %v. Expected a message and an optional error, got %v."
+	unsupportedResultMessage = "Message %v returns %v. g11n-gen supports results of a string kind and error."
+	escapedResultMessage     = "Message %v returns %v, whose parameters g11n-gen does not escape."
+	placeholdersMessage      = "The %v message %v uses placeholders in braces, which g11n-gen does not support."
+	missingArgsMessage       = "The %v message %v uses %v arguments, the message func has %v."
+	unusedArgMessage         = "The %v message %v does not use argument %v of the message func."
+)
+
+func main() {
+	var locales cli.LocaleFlags
+
+	typeNames := flag.String("type", "", "comma-separated list of message struct names")
+	formatName := flag.String("format", "", "format of the locale files; inferred from the file extension if empty")
+	output := flag.String("output", "", "output file name; default <type>_g11n.go")
+	flag.Var(&locales, "locale", "locale file as tag=path; may be repeated")
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+
+	locales.SetFormat(*formatName)
+
+	if err := run(dir, *typeNames, *output, locales); err != nil {
+		fmt.Fprintln(os.Stderr, "g11n-gen:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the initializers of the message structs of a package.
+func run(dir, typeNames, output string, localeFiles []cli.LocaleFile) error {
+	if typeNames == "" {
+		return errors.New(missingTypeFlagsMessage)
+	}
+
+	dictionaries, err := loadLocales(localeFiles)
+	if err != nil {
+		return err
+	}
+
+	pkgs, err := cli.LoadPackages(dir, ".")
+	if err != nil {
+		return err
+	}
+	pkg := pkgs[0].Types
+
+	var structs []*scan.Struct
+	for _, name := range strings.Split(typeNames, ",") {
+		object, ok := pkg.Scope().Lookup(strings.TrimSpace(name)).(*types.TypeName)
+		if !ok {
+			return fmt.Errorf(unknownTypeMessage, name)
+		}
+
+		named, ok := object.Type().(*types.Named)
+		if !ok {
+			return fmt.Errorf(unknownTypeMessage, name)
+		}
+
+		s := scan.Load(named)
+		if s == nil {
+			return fmt.Errorf(unknownTypeMessage, name)
+		}
+
+		structs = append(structs, s)
+	}
+
+	source, err := newGenerator(pkg, dictionaries).generate(structs)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		firstType := strings.TrimSpace(strings.Split(typeNames, ",")[0])
+		output = filepath.Join(dir, strings.ToLower(firstType)+"_g11n.go")
+	}
+
+	return ioutil.WriteFile(output, source, 0644)
+}
+
+// loadLocales loads the locale files passed on the command line.
+func loadLocales(localeFiles []cli.LocaleFile) ([]localeDictionary, error) {
+	var dictionaries []localeDictionary
+
+	for _, localeFile := range localeFiles {
+		loader, ok := locale.GetLoader(localeFile.Format)
+		if !ok {
+			return nil, fmt.Errorf(unknownFormatMessage, localeFile.Format)
+		}
+
+		dictionaries = append(dictionaries, localeDictionary{
+			tag:        localeFile.Tag,
+			dictionary: loader.Load(localeFile.Path),
+		})
+	}
+
+	return dictionaries, nil
+}
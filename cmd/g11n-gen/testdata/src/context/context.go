@@ -0,0 +1,7 @@
+package context
+
+type Context interface {
+	Done() <-chan struct{}
+	Err() error
+	Value(key interface{}) interface{}
+}
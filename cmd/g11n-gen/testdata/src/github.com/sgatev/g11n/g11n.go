@@ -0,0 +1,41 @@
+package g11n
+
+import (
+	"html/template"
+
+	"golang.org/x/text/language"
+)
+
+type MessageInfo struct {
+	Tag  language.Tag
+	Key  string
+	Args []interface{}
+}
+
+type Message struct {
+	Key  string
+	Args []interface{}
+}
+
+func (m Message) String() string {
+	return m.Key
+}
+
+type Error struct {
+	Key  string
+	Args []interface{}
+}
+
+func (e *Error) Error() string {
+	return e.Key
+}
+
+type SafeHTML string
+
+func (SafeHTML) G11nEscape(formattedParam string) string {
+	return formattedParam
+}
+
+func (sh SafeHTML) HTML() template.HTML {
+	return template.HTML(sh)
+}
@@ -0,0 +1,7 @@
+package language
+
+type Tag struct{}
+
+func (t Tag) String() string {
+	return ""
+}
@@ -0,0 +1,3 @@
+package template
+
+type HTML string
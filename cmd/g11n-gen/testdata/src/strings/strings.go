@@ -0,0 +1,5 @@
+package strings
+
+func ToUpper(s string) string {
+	return s
+}
@@ -0,0 +1,5 @@
+package time
+
+type Time struct{}
+
+type Duration int64
@@ -0,0 +1,44 @@
+package unsupported
+
+import (
+	"html/template"
+	"time"
+
+	"github.com/sgatev/g11n"
+)
+
+type Results struct {
+	Results func() (string, int) `default:"Oops!"`
+}
+
+type Lazy struct {
+	Lazy func() g11n.Message `default:"Lazy"`
+}
+
+type Localized struct {
+	Localized func() *g11n.Error `default:"Localized"`
+}
+
+type SafeHTML struct {
+	SafeHTML func(string) g11n.SafeHTML `default:"<b>%v</b>"`
+}
+
+type HTML struct {
+	HTML func(string) template.HTML `default:"<b>%v</b>"`
+}
+
+type Placeholders struct {
+	Placeholders func(time.Time) string `default:"Due {1, date, short}"`
+}
+
+type MissingArgs struct {
+	MissingArgs func(string) string `default:"%v and %v"`
+}
+
+type UnusedArgs struct {
+	UnusedArgs func(string, string) string `default:"Hi %v!"`
+}
+
+type Invalid struct {
+	Invalid int `default:"Invalid"`
+}
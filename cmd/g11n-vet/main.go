@@ -0,0 +1,16 @@
+// Command g11n-vet checks g11n message structs.
+//
+// It could be run standalone or as a go vet tool:
+//
+//	go vet -vettool=$(which g11n-vet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/sgatev/g11n/vet"
+)
+
+func main() {
+	singlechecker.Main(vet.Analyzer)
+}
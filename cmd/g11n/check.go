@@ -0,0 +1,294 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sgatev/g11n/internal/cli"
+	"github.com/sgatev/g11n/internal/scan"
+	"github.com/sgatev/g11n/pattern"
+)
+
+// Check rules.
+const (
+	missingKeyRule          = "missing-key"
+	obsoleteKeyRule         = "obsolete-key"
+	placeholderMismatchRule = "placeholder-mismatch"
+	emptyTranslationRule    = "empty-translation"
+	maxLengthRule           = "max-length"
+)
+
+// Issue severities.
+const (
+	errorSeverity   = "error"
+	warningSeverity = "warning"
+)
+
+// Check message patterns.
+const (
+	missingKeyMessage          = "%v is not translated."
+	obsoleteKeyMessage         = "%v is not a message key."
+	placeholderMismatchMessage = "%v uses arguments %v, the default message uses %v."
+	emptyTranslationMessage    = "%v has an empty translation."
+	maxLengthMessage           = "%v is %v characters long, the limit is %v."
+	checkFailedMessage         = "%v errors found."
+	unknownReportMessage       = "Unknown report format '%v'."
+)
+
+// rules describes the check rules.
+var rules = []struct {
+	id          string
+	description string
+}{
+	{missingKeyRule, "A message key has no translation in the locale."},
+	{obsoleteKeyRule, "A translation does not belong to any message key."},
+	{placeholderMismatchRule, "A translation references other arguments than the default message."},
+	{emptyTranslationRule, "A translation is empty."},
+	{maxLengthRule, "A translation is longer than the maxlen tag of its message."},
+}
+
+// issue is a problem found in a locale file.
+type issue struct {
+	Locale   string `json:"locale"`
+	File     string `json:"file"`
+	Key      string `json:"key"`
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// reporters maps report formats to their implementations.
+var reporters = map[string]func(w io.Writer, issues []issue) error{
+	"text":  textReport,
+	"json":  jsonReport,
+	"sarif": sarifReport,
+}
+
+// check compares the locale files with the message structs.
+func check(args []string) error {
+	var locales cli.LocaleFlags
+
+	flags := flag.NewFlagSet("check", flag.ExitOnError)
+	formatName := flags.String("format", "", "format of the locale files; inferred from the file extension if empty")
+	reportName := flags.String("report", "text", "report format: text, json or sarif")
+	strict := flags.Bool("strict", false, "treat warnings as errors")
+	flags.Var(&locales, "locale", "locale file as tag=path; may be repeated")
+	flags.Parse(args)
+
+	locales.SetFormat(*formatName)
+
+	reporter, ok := reporters[*reportName]
+	if !ok {
+		return fmt.Errorf(unknownReportMessage, *reportName)
+	}
+
+	messages, err := loadMessages(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	var issues []issue
+	for _, localeFile := range locales {
+		translations, err := loadTranslations(localeFile)
+		if err != nil {
+			return err
+		}
+
+		issues = append(issues, checkLocale(localeFile, messages, translations)...)
+	}
+
+	if err := reporter(os.Stdout, issues); err != nil {
+		return err
+	}
+
+	errorsCount := 0
+	for _, issue := range issues {
+		if issue.Severity == errorSeverity || *strict {
+			errorsCount++
+		}
+	}
+
+	if errorsCount > 0 {
+		return fmt.Errorf(checkFailedMessage, errorsCount)
+	}
+
+	return nil
+}
+
+// checkLocale returns the issues of the translations of a locale.
+func checkLocale(localeFile cli.LocaleFile, messages []*scan.Field, dictionary map[string]string) []issue {
+	var issues []issue
+
+	report := func(key, rule, severity, message string) {
+		issues = append(issues, issue{
+			Locale:   localeFile.Tag.String(),
+			File:     localeFile.Path,
+			Key:      key,
+			Rule:     rule,
+			Severity: severity,
+			Message:  message,
+		})
+	}
+
+	known := map[string]bool{}
+	for _, message := range messages {
+		known[message.Key] = true
+
+		translation, ok := dictionary[message.Key]
+		switch {
+		case !ok:
+			report(message.Key, missingKeyRule, errorSeverity,
+				fmt.Sprintf(missingKeyMessage, message.Key))
+		case strings.TrimSpace(translation) == "":
+			report(message.Key, emptyTranslationRule, errorSeverity,
+				fmt.Sprintf(emptyTranslationMessage, message.Key))
+		case message.Kind == scan.FuncField:
+			expected, actual := argNumbers(message.Default), argNumbers(translation)
+			if !reflect.DeepEqual(expected, actual) {
+				report(message.Key, placeholderMismatchRule, errorSeverity,
+					fmt.Sprintf(placeholderMismatchMessage, message.Key, actual, expected))
+			}
+		}
+
+		if length := utf8.RuneCountInString(translation); message.MaxLength > 0 && length > message.MaxLength {
+			report(message.Key, maxLengthRule, errorSeverity,
+				fmt.Sprintf(maxLengthMessage, message.Key, length, message.MaxLength))
+		}
+	}
+
+	var obsolete []string
+	for key := range dictionary {
+		if !known[key] {
+			obsolete = append(obsolete, key)
+		}
+	}
+	sort.Strings(obsolete)
+
+	for _, key := range obsolete {
+		report(key, obsoleteKeyRule, warningSeverity, fmt.Sprintf(obsoleteKeyMessage, key))
+	}
+
+	return issues
+}
+
+// textReport writes the issues one per line.
+func textReport(w io.Writer, issues []issue) error {
+	for _, issue := range issues {
+		if _, err := fmt.Fprintf(w, "%v: %v: %v: %v\n",
+			issue.File, issue.Severity, issue.Rule, issue.Message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jsonReport writes the issues as a JSON array.
+func jsonReport(w io.Writer, issues []issue) error {
+	if issues == nil {
+		issues = []issue{}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(issues)
+}
+
+// sarifReport writes the issues as a SARIF 2.1.0 log.
+func sarifReport(w io.Writer, issues []issue) error {
+	type text struct {
+		Text string `json:"text"`
+	}
+
+	type rule struct {
+		ID               string `json:"id"`
+		ShortDescription text   `json:"shortDescription"`
+	}
+
+	type artifactLocation struct {
+		URI string `json:"uri"`
+	}
+
+	type physicalLocation struct {
+		ArtifactLocation artifactLocation `json:"artifactLocation"`
+	}
+
+	type location struct {
+		PhysicalLocation physicalLocation `json:"physicalLocation"`
+	}
+
+	type result struct {
+		RuleID    string     `json:"ruleId"`
+		Level     string     `json:"level"`
+		Message   text       `json:"message"`
+		Locations []location `json:"locations"`
+	}
+
+	type driver struct {
+		Name  string `json:"name"`
+		Rules []rule `json:"rules"`
+	}
+
+	type tool struct {
+		Driver driver `json:"driver"`
+	}
+
+	type run struct {
+		Tool    tool     `json:"tool"`
+		Results []result `json:"results"`
+	}
+
+	type log struct {
+		Version string `json:"version"`
+		Schema  string `json:"$schema"`
+		Runs    []run  `json:"runs"`
+	}
+
+	var sarifRules []rule
+	for _, r := range rules {
+		sarifRules = append(sarifRules, rule{ID: r.id, ShortDescription: text{r.description}})
+	}
+
+	results := []result{}
+	for _, issue := range issues {
+		results = append(results, result{
+			RuleID:  issue.Rule,
+			Level:   issue.Severity,
+			Message: text{issue.Message},
+			Locations: []location{{
+				PhysicalLocation: physicalLocation{artifactLocation{issue.File}},
+			}},
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(log{
+		Version: "2.1.0",
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Runs: []run{{
+			Tool:    tool{driver{Name: "g11n", Rules: sarifRules}},
+			Results: results,
+		}},
+	})
+}
+
+// argNumbers returns the one-based numbers of the arguments referenced by
+// a pattern, as they are written in explicit indexes and placeholders.
+func argNumbers(messagePattern string) []int {
+	args := pattern.Args(messagePattern)
+	for i := range args {
+		args[i]++
+	}
+
+	return args
+}
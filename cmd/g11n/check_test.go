@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/sgatev/g11n/internal/cli"
+	"github.com/sgatev/g11n/internal/scan"
+)
+
+var checkMessages = []*scan.Field{
+	{Key: "M.Hello", Default: "Hi %v!", Kind: scan.FuncField},
+	{Key: "M.Answer", Default: "The answer to %v is %v.", Kind: scan.FuncField},
+	{Key: "M.Title", Default: "Title", Kind: scan.StringField},
+	{Key: "M.Bye", Default: "Bye!", Kind: scan.FuncField},
+}
+
+var checkLocaleFile = cli.LocaleFile{
+	Tag:    language.Bulgarian,
+	Path:   "bg.json",
+	Format: "json",
+}
+
+func TestCheckLocale(t *testing.T) {
+	actual := checkLocale(checkLocaleFile, checkMessages, map[string]string{
+		"M.Hello":  "Здравей %v!",
+		"M.Answer": "Отговорът е %[2]v.",
+		"M.Title":  " ",
+		"M.Old":    "Старо",
+	})
+
+	expected := []issue{
+		{"bg", "bg.json", "M.Answer", "placeholder-mismatch", "error", "M.Answer uses arguments [2], the default message uses [1 2]."},
+		{"bg", "bg.json", "M.Title", "empty-translation", "error", "M.Title has an empty translation."},
+		{"bg", "bg.json", "M.Bye", "missing-key", "error", "M.Bye is not translated."},
+		{"bg", "bg.json", "M.Old", "obsolete-key", "warning", "M.Old is not a message key."},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Issues are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}
+
+func TestCheckLocaleMaxLength(t *testing.T) {
+	messages := []*scan.Field{
+		{Key: "M.Pay", Default: "Pay", Kind: scan.FuncField, MaxLength: 5},
+		{Key: "M.Title", Default: "Title", Kind: scan.StringField, MaxLength: 5},
+	}
+
+	actual := checkLocale(checkLocaleFile, messages, map[string]string{
+		"M.Pay":   "Плащане",
+		"M.Title": "Плати",
+	})
+
+	expected := []issue{
+		{"bg", "bg.json", "M.Pay", "max-length", "error", "M.Pay is 7 characters long, the limit is 5."},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Issues are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}
+
+func TestTextReport(t *testing.T) {
+	var buf bytes.Buffer
+	textReport(&buf, []issue{
+		{"bg", "bg.json", "M.Bye", "missing-key", "error", "M.Bye is not translated."},
+	})
+
+	expected := "bg.json: error: missing-key: M.Bye is not translated.\n"
+	if actual := buf.String(); actual != expected {
+		t.Errorf("Report is not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}
+
+func TestSarifReport(t *testing.T) {
+	var buf bytes.Buffer
+	sarifReport(&buf, []issue{
+		{"bg", "bg.json", "M.Bye", "missing-key", "error", "M.Bye is not translated."},
+	})
+
+	var log struct {
+		Version string
+		Runs    []struct {
+			Results []struct {
+				RuleID    string
+				Level     string
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string
+						}
+					}
+				}
+			}
+		}
+	}
+	if err := json.Unmarshal(buf.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+
+	result := log.Runs[0].Results[0]
+	if log.Version != "2.1.0" ||
+		result.RuleID != "missing-key" ||
+		result.Level != "error" ||
+		result.Locations[0].PhysicalLocation.ArtifactLocation.URI != "bg.json" {
+		t.Errorf("SARIF report is not correct: %v", buf.String())
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+
+	"github.com/sgatev/g11n/internal/cli"
+	"github.com/sgatev/g11n/internal/scan"
+	"github.com/sgatev/g11n/locale"
+)
+
+// Notes marking the state of extracted entries.
+const (
+	newNote      = "new"
+	obsoleteNote = "obsolete"
+)
+
+// maxLengthNote is the note pattern of the max length of a message.
+const maxLengthNote = "max %v characters"
+
+// extract creates or updates the locale files of the message structs.
+func extract(args []string) error {
+	var locales cli.LocaleFlags
+
+	flags := flag.NewFlagSet("extract", flag.ExitOnError)
+	formatName := flags.String("format", "", "format of the locale files; inferred from the file extension if empty")
+	prune := flags.Bool("prune", false, "remove obsolete entries instead of marking them")
+	flags.Var(&locales, "locale", "locale file as tag=path; may be repeated")
+	flags.Parse(args)
+
+	locales.SetFormat(*formatName)
+
+	messages, err := loadMessages(flags.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, localeFile := range locales {
+		translations, err := loadTranslations(localeFile)
+		if err != nil {
+			return err
+		}
+
+		writer, ok := locale.GetWriter(localeFile.Format)
+		if !ok {
+			return fmt.Errorf(unknownFormatMessage, localeFile.Format)
+		}
+
+		entries := extractEntries(messages, translations, *prune)
+		if err := writer.Write(localeFile.Path, entries); err != nil {
+			return err
+		}
+
+		fmt.Printf("%v: %v new, %v obsolete\n", localeFile.Path,
+			countNotes(entries, newNote), countNotes(entries, obsoleteNote))
+	}
+
+	return nil
+}
+
+// extractEntries merges the messages of the message structs with the
+// existing translations of a locale. Untranslated messages get their default
+// pattern and are marked as new until a translator removes the note. The
+// description and the max length of a message are added as notes for
+// translators. Translations of unknown keys are kept at the end and marked as
+// obsolete unless prune is set.
+func extractEntries(messages []*scan.Field, existing map[string]string, prune bool) []locale.Entry {
+	var entries []locale.Entry
+	known := map[string]bool{}
+
+	for _, message := range messages {
+		known[message.Key] = true
+
+		entry := locale.Entry{Key: message.Key}
+		if message.Description != "" {
+			entry.Notes = append(entry.Notes, message.Description)
+		}
+		if message.MaxLength > 0 {
+			entry.Notes = append(entry.Notes, fmt.Sprintf(maxLengthNote, message.MaxLength))
+		}
+
+		if translation, ok := existing[message.Key]; ok {
+			entry.Message = translation
+		} else {
+			entry.Message = message.Default
+			entry.Notes = append(entry.Notes, newNote)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if prune {
+		return entries
+	}
+
+	var obsolete []string
+	for key := range existing {
+		if !known[key] {
+			obsolete = append(obsolete, key)
+		}
+	}
+	sort.Strings(obsolete)
+
+	for _, key := range obsolete {
+		entries = append(entries, locale.Entry{
+			Key:     key,
+			Message: existing[key],
+			Notes:   []string{obsoleteNote},
+		})
+	}
+
+	return entries
+}
+
+// countNotes counts the entries marked with a note.
+func countNotes(entries []locale.Entry, note string) int {
+	count := 0
+	for _, entry := range entries {
+		for _, entryNote := range entry.Notes {
+			if entryNote == note {
+				count++
+			}
+		}
+	}
+
+	return count
+}
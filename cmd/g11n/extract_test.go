@@ -0,0 +1,119 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	"github.com/sgatev/g11n/internal/cli"
+	"github.com/sgatev/g11n/internal/scan"
+	"github.com/sgatev/g11n/locale"
+	. "github.com/sgatev/g11n/test"
+)
+
+var extractMessages = []*scan.Field{
+	{Key: "M.Hello", Default: "Hi %v!"},
+	{Key: "M.Bye", Default: "Bye!"},
+}
+
+func testEntries(t *testing.T, actual, expected []locale.Entry) {
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Entries are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}
+
+func TestExtractEntries(t *testing.T) {
+	existing := map[string]string{
+		"M.Hello": "Здравей %v!",
+		"M.Old":   "Старо",
+	}
+
+	testEntries(t, extractEntries(extractMessages, existing, false), []locale.Entry{
+		{Key: "M.Hello", Message: "Здравей %v!"},
+		{Key: "M.Bye", Message: "Bye!", Notes: []string{"new"}},
+		{Key: "M.Old", Message: "Старо", Notes: []string{"obsolete"}},
+	})
+}
+
+func TestExtractEntriesPrune(t *testing.T) {
+	existing := map[string]string{
+		"M.Hello": "Здравей %v!",
+		"M.Old":   "Старо",
+	}
+
+	testEntries(t, extractEntries(extractMessages, existing, true), []locale.Entry{
+		{Key: "M.Hello", Message: "Здравей %v!"},
+		{Key: "M.Bye", Message: "Bye!", Notes: []string{"new"}},
+	})
+}
+
+func TestExtractEntriesAnnotations(t *testing.T) {
+	messages := []*scan.Field{
+		{Key: "M.Pay", Default: "Pay", Description: "Checkout button", MaxLength: 10},
+		{Key: "M.Title", Default: "Title", MaxLength: 20},
+	}
+
+	existing := map[string]string{
+		"M.Pay": "Плати",
+	}
+
+	testEntries(t, extractEntries(messages, existing, false), []locale.Entry{
+		{Key: "M.Pay", Message: "Плати", Notes: []string{"Checkout button", "max 10 characters"}},
+		{Key: "M.Title", Message: "Title", Notes: []string{"max 20 characters", "new"}},
+	})
+}
+
+func TestExtractEntriesNewLocale(t *testing.T) {
+	testEntries(t, extractEntries(extractMessages, map[string]string{}, false), []locale.Entry{
+		{Key: "M.Hello", Message: "Hi %v!", Notes: []string{"new"}},
+		{Key: "M.Bye", Message: "Bye!", Notes: []string{"new"}},
+	})
+}
+
+// extractFile extracts the messages into a locale file like the extract
+// command and returns the written entries.
+func extractFile(t *testing.T, localeFile cli.LocaleFile, messages []*scan.Field) []locale.Entry {
+	translations, err := loadTranslations(localeFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entries := extractEntries(messages, translations, false)
+
+	writer, _ := locale.GetWriter(localeFile.Format)
+	if err := writer.Write(localeFile.Path, entries); err != nil {
+		t.Fatal(err)
+	}
+
+	return entries
+}
+
+func TestExtractRoundTrip(t *testing.T) {
+	messages := []*scan.Field{
+		{Key: "M.Hello", Default: "Hi %v!", Kind: scan.FuncField, Description: "Greeting"},
+		{Key: "M.Bye", Default: "Bye!", Kind: scan.FuncField},
+	}
+
+	for _, format := range []string{"json", "yaml"} {
+		localeFile := cli.LocaleFile{
+			Tag:    language.Bulgarian,
+			Path:   TempFile(""),
+			Format: format,
+		}
+
+		extractFile(t, localeFile, messages)
+		testEntries(t, extractFile(t, localeFile, messages), []locale.Entry{
+			{Key: "M.Hello", Message: "Hi %v!", Notes: []string{"Greeting", "new"}},
+			{Key: "M.Bye", Message: "Bye!", Notes: []string{"new"}},
+		})
+
+		translations, _ := loadTranslations(localeFile)
+		issues := checkLocale(localeFile, messages, translations)
+		if len(issues) != 2 || issues[0].Rule != missingKeyRule || issues[1].Rule != missingKeyRule {
+			t.Errorf("Untranslated %v entries are not reported: %v", format, issues)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// Command g11n manages the locale files of g11n message structs.
+//
+// Usage:
+//
+//	g11n <command> [flags] [packages]
+//
+// The commands are:
+//
+//	check      report problems in locale files
+//	extract    create or update locale files from message structs
+//
+// Message structs are discovered statically in the packages (./... by
+// default): a struct is a message struct if a pointer to it is passed to
+// MessageFactory.Init or if its declaration is marked with a //g11n:messages
+// comment. Locale files are passed as -locale tag=path flags and their format
+// is inferred from the file extension unless -format is set.
+//
+// The check command exits with a non-zero status if any locale file has
+// missing keys, empty translations or references to other arguments than the
+// default message. Obsolete keys are reported as warnings. The report is
+// written as text, JSON or SARIF depending on the -report flag.
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sgatev/g11n/internal/cli"
+	"github.com/sgatev/g11n/internal/scan"
+	"github.com/sgatev/g11n/locale"
+)
+
+// Error message patterns.
+const (
+	unknownCommandMessage = "Unknown command '%v'."
+	unknownFormatMessage  = "Unknown locale format '%v'."
+	usageMessage          = "Usage: g11n <%v> [flags] [packages]"
+)
+
+// commands maps command names to their implementations.
+var commands = map[string]func(args []string) error{
+	"check":   check,
+	"extract": extract,
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, usage())
+		os.Exit(2)
+	}
+
+	command, ok := commands[os.Args[1]]
+	if !ok {
+		fmt.Fprintf(os.Stderr, unknownCommandMessage+"\n%v\n", os.Args[1], usage())
+		os.Exit(2)
+	}
+
+	if err := command(os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "g11n:", err)
+		os.Exit(1)
+	}
+}
+
+// usage returns the usage message of the tool.
+func usage() string {
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return fmt.Sprintf(usageMessage, strings.Join(names, "|"))
+}
+
+// loadMessages returns the message fields of the message structs found in
+// the packages matching patterns, keyed and ordered as they are declared.
+func loadMessages(patterns []string) ([]*scan.Field, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+
+	pkgs, err := cli.LoadPackages(".", patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	var messages []*scan.Field
+	seen := map[string]bool{}
+
+	for _, pkg := range pkgs {
+		for _, named := range scan.Find(pkg.Types, pkg.TypesInfo, pkg.Syntax) {
+			s := scan.Load(named)
+			if s == nil {
+				continue
+			}
+
+			for _, message := range s.Messages() {
+				if !seen[message.Key] {
+					seen[message.Key] = true
+					messages = append(messages, message)
+				}
+			}
+		}
+	}
+
+	return messages, nil
+}
+
+// loadTranslations returns the translated messages of a locale file. The
+// messages that extract marked as new still hold their default pattern and
+// are left out until a translator removes the note.
+func loadTranslations(localeFile cli.LocaleFile) (map[string]string, error) {
+	loader, ok := locale.GetLoader(localeFile.Format)
+	if !ok {
+		return nil, fmt.Errorf(unknownFormatMessage, localeFile.Format)
+	}
+
+	translations := loader.Load(localeFile.Path)
+
+	if notesLoader, ok := loader.(locale.NotesLoader); ok {
+		for key, notes := range notesLoader.LoadNotes(localeFile.Path) {
+			for _, note := range notes {
+				if note == newNote {
+					delete(translations, key)
+				}
+			}
+		}
+	}
+
+	return translations, nil
+}
@@ -0,0 +1,324 @@
+package g11n
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/sgatev/g11n/pattern"
+)
+
+// compiledPattern is a message pattern that is parsed once, when its locale
+// is loaded or its struct is initialized.
+type compiledPattern struct {
+
+	// source is the pattern as written.
+	source string
+
+	// count is the number of arguments referenced by the pattern.
+	count int
+
+	// referenced is the number of distinct arguments referenced by the
+	// pattern, which is less than count if some argument is skipped.
+	referenced int
+
+	// placeholders are the placeholders in braces of the pattern.
+	placeholders []compiledPlaceholder
+
+	// verbs are the formatting verbs of the pattern, including those of the
+	// cases of select placeholders.
+	verbs []pattern.Verb
+
+	// malformed is set if the pattern has verbs that fmt can not parse or
+	// percent signs that are not escaped.
+	malformed bool
+
+	// segments are the literal texts and the arguments of a direct pattern,
+	// i.e. one that only substitutes all of its arguments with %v or %s.
+	segments []segment
+	direct   bool
+}
+
+// segment is a literal text or an argument of a direct pattern.
+type segment struct {
+
+	// text is the literal text of the segment with %% unescaped.
+	text string
+
+	// arg is the zero-based index of the substituted argument or -1 for
+	// literal text.
+	arg int
+}
+
+// compiledPlaceholder is a placeholder in braces of a compiled pattern.
+type compiledPlaceholder struct {
+	pattern.Placeholder
+
+	// cases are the cases of a select placeholder, or nil if the placeholder
+	// is not a select placeholder or its cases could not be parsed.
+	cases []compiledCase
+}
+
+// compiledCase is a case of a select placeholder with the placeholders of
+// its pattern parsed.
+type compiledCase struct {
+	name         string
+	source       string
+	placeholders []compiledPlaceholder
+}
+
+// compilePlaceholders parses the placeholders of a pattern, including those
+// of the cases of its select placeholders.
+func compilePlaceholders(source string) []compiledPlaceholder {
+	parsed := pattern.ParsePlaceholders(source)
+	if len(parsed) == 0 {
+		return nil
+	}
+
+	placeholders := make([]compiledPlaceholder, len(parsed))
+	for i, placeholder := range parsed {
+		placeholders[i].Placeholder = placeholder
+		if placeholder.Type != pattern.SelectType {
+			continue
+		}
+
+		cases, ok := pattern.ParseCases(placeholder.Style)
+		if !ok {
+			continue
+		}
+
+		for _, c := range cases {
+			placeholders[i].cases = append(placeholders[i].cases, compiledCase{
+				name:         c.Name,
+				source:       c.Pattern,
+				placeholders: compilePlaceholders(c.Pattern),
+			})
+		}
+	}
+
+	return placeholders
+}
+
+// selected returns the case of a select placeholder with the given name,
+// falling back to the other case and then to an empty one.
+func (cp compiledPlaceholder) selected(name string) compiledCase {
+	var other compiledCase
+	for _, c := range cp.cases {
+		if c.name == name {
+			return c
+		}
+		if c.name == pattern.OtherCase {
+			other = c
+		}
+	}
+
+	return other
+}
+
+// bufferPool holds the buffers that direct patterns are rendered in.
+var bufferPool = sync.Pool{
+	New: func() interface{} { return new(bytes.Buffer) },
+}
+
+// compilePattern parses a message pattern.
+func compilePattern(source string) *compiledPattern {
+	compiled := &compiledPattern{
+		source:       source,
+		count:        pattern.Count(source),
+		referenced:   len(pattern.Args(source)),
+		placeholders: compilePlaceholders(source),
+		verbs:        pattern.Parse(source),
+	}
+	compiled.malformed = isMalformed(source, compiled.verbs)
+
+	if len(compiled.placeholders) == 0 {
+		compiled.segments, compiled.direct = directSegments(source, compiled.count)
+	}
+
+	return compiled
+}
+
+// directSegments splits a pattern into segments if it substitutes each of
+// its arguments only with %v or %s, without flags, width or precision.
+func directSegments(source string, count int) ([]segment, bool) {
+	var segments []segment
+	used := make([]bool, count)
+
+	last := 0
+	for _, verb := range pattern.Parse(source) {
+		if !isDirectVerb(source[verb.Start:verb.End]) {
+			return nil, false
+		}
+
+		text, ok := literal(source[last:verb.Start])
+		if !ok {
+			return nil, false
+		}
+		if text != "" {
+			segments = append(segments, segment{text: text, arg: -1})
+		}
+
+		segments = append(segments, segment{arg: verb.Arg})
+		used[verb.Arg] = true
+		last = verb.End
+	}
+
+	text, ok := literal(source[last:])
+	if !ok {
+		return nil, false
+	}
+	if text != "" {
+		segments = append(segments, segment{text: text, arg: -1})
+	}
+
+	for _, isUsed := range used {
+		if !isUsed {
+			return nil, false
+		}
+	}
+
+	return segments, true
+}
+
+// isDirectVerb reports whether a verb is %v or %s with an optional explicit
+// argument index.
+func isDirectVerb(verb string) bool {
+	last := verb[len(verb)-1]
+	if last != 'v' && last != 's' {
+		return false
+	}
+
+	index := verb[1 : len(verb)-1]
+	if index == "" {
+		return true
+	}
+
+	return len(index) > 2 && index[0] == '[' && index[len(index)-1] == ']' &&
+		strings.Trim(index[1:len(index)-1], "0123456789") == ""
+}
+
+// literal unescapes the literal text of a pattern. It fails if the text has
+// a percent sign that is not escaped.
+func literal(text string) (string, bool) {
+	if strings.Contains(strings.Replace(text, "%%", "", -1), "%") {
+		return "", false
+	}
+
+	return strings.Replace(text, "%%", "%", -1), true
+}
+
+// formatDirect renders a direct pattern whose arguments are all strings.
+// It reports false if the pattern has to be formatted by a printer instead.
+func (cp *compiledPattern) formatDirect(args []reflect.Value, escaper paramEscaper) (string, bool) {
+	if !cp.direct || len(args) != cp.count {
+		return "", false
+	}
+
+	for _, arg := range args {
+		if arg.Type() != stringType {
+			return "", false
+		}
+	}
+
+	switch {
+	case len(cp.segments) == 0:
+		return "", true
+	case len(cp.segments) == 1 && cp.segments[0].arg < 0:
+		return cp.segments[0].text, true
+	}
+
+	buffer := bufferPool.Get().(*bytes.Buffer)
+	defer bufferPool.Put(buffer)
+	buffer.Reset()
+
+	for _, segment := range cp.segments {
+		switch {
+		case segment.arg < 0:
+			buffer.WriteString(segment.text)
+		case escaper != nil:
+			buffer.WriteString(escaper.G11nEscape(args[segment.arg].String()))
+		default:
+			buffer.WriteString(args[segment.arg].String())
+		}
+	}
+
+	return buffer.String(), true
+}
+
+// fmtVerbs are the verbs that package fmt formats.
+const fmtVerbs = "bcdeEfFgGopqsStTUvxX"
+
+// isMalformed reports whether a pattern has verbs that fmt can not parse or
+// percent signs that do not start a verb.
+func isMalformed(source string, verbs []pattern.Verb) bool {
+	last := 0
+	for _, verb := range verbs {
+		if !strings.ContainsRune(fmtVerbs, verb.Verb) {
+			return true
+		}
+		if _, ok := literal(source[last:verb.Start]); !ok {
+			return true
+		}
+		last = verb.End
+	}
+
+	_, ok := literal(source[last:])
+
+	return !ok
+}
+
+// referencesAll reports whether the pattern references each of n arguments
+// and no other argument.
+func (cp *compiledPattern) referencesAll(n int) bool {
+	return cp.count == n && cp.referenced == n
+}
+
+// mismatchedVerb reports whether a verb of a pattern can not format its
+// parameter, e.g. %d of a string.
+func (cp *compiledPattern) mismatchedVerb(params []interface{}) bool {
+	for _, verb := range cp.verbs {
+		if verb.Arg < len(params) && !acceptsVerb(params[verb.Arg], verb.Verb) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// acceptsVerb reports whether fmt formats a value with a verb.
+func acceptsVerb(value interface{}, verb rune) bool {
+	if verb == 'v' || verb == 'T' {
+		return true
+	}
+
+	switch value.(type) {
+	case fmt.Formatter:
+		return true
+	case error, fmt.Stringer:
+		if strings.ContainsRune("sqxX", verb) {
+			return true
+		}
+	}
+
+	var verbs string
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Bool:
+		verbs = "t"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		verbs = "bcdoOqxXU"
+	case reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		verbs = "beEfFgGxX"
+	case reflect.String:
+		verbs = "sqxX"
+	case reflect.Ptr, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		verbs = "pbdoxX"
+	default:
+		// Composite values are formatted element by element.
+		return true
+	}
+
+	return strings.ContainsRune(verbs, verb)
+}
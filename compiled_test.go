@@ -0,0 +1,54 @@
+package g11n_test
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+type CompiledMessages struct {
+	Percent  func(string) string         `default:"100%% %v"`
+	Reorder  func(string, string) string `default:"%[2]v, %[1]s"`
+	Repeat   func(string) string         `default:"%v %[1]v"`
+	Unused   func(string, string) string `default:"%v"`
+	Trailing func(string) string         `default:"%v 100%"`
+	Padded   func(string) string         `default:"[%5v]"`
+	Named    func(Fruit) string          `default:"I like %v."`
+	Escaped  func(string) SafeHTML       `default:"<i>%v</i> and %%"`
+}
+
+// Fruit is a named string parameter.
+type Fruit string
+
+func TestCompiledPatterns(t *testing.T) {
+	m := New().Init(&CompiledMessages{}).(*CompiledMessages)
+
+	testMessage(t, m.Percent("cats"), "100% cats")
+	testMessage(t, m.Reorder("Ana", "Bob"), "Bob, Ana")
+	testMessage(t, m.Repeat("meow"), "meow meow")
+	testMessage(t, m.Unused("Ana", "Bob"), "Ana%!(EXTRA string=Bob)")
+	testMessage(t, m.Trailing("Ana"), "Ana 100%!(NOVERB)")
+	testMessage(t, m.Padded("Ana"), "[  Ana]")
+	testMessage(t, m.Named("apples"), "I like apples.")
+	testMessage(t, string(m.Escaped("<b>")), "<i>&lt;b&gt;</i> and %")
+}
+
+func TestCompiledTranslations(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "CompiledMessages.Percent": "%v на 100%%",
+	  "CompiledMessages.Reorder": "%[1]v и %[2]v"
+	}
+`))
+	m := factory.Init(&CompiledMessages{}).(*CompiledMessages)
+
+	factory.LoadLocale(language.Bulgarian)
+
+	testMessage(t, m.Percent("котки"), "котки на 100%")
+	testMessage(t, m.Reorder("Ана", "Боб"), "Ана и Боб")
+	testMessage(t, m.Repeat("мяу"), "мяу мяу")
+}
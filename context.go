@@ -0,0 +1,59 @@
+package g11n
+
+import (
+	"context"
+	"reflect"
+
+	"golang.org/x/text/language"
+)
+
+// contextType is the reflected type of context.Context.
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+// localeContextKey is the context key of the locale of a message call.
+type localeContextKey struct{}
+
+// WithLocale returns a copy of a context that carries a locale. Message funcs
+// whose first parameter is a context.Context are formatted in that locale.
+func WithLocale(ctx context.Context, tag language.Tag) context.Context {
+	return context.WithValue(ctx, localeContextKey{}, tag)
+}
+
+// LocaleFromContext returns the locale carried by a context.
+func LocaleFromContext(ctx context.Context) (language.Tag, bool) {
+	tag, ok := ctx.Value(localeContextKey{}).(language.Tag)
+	return tag, ok
+}
+
+// isContextFunc reports whether the first parameter of a message func is
+// a context.Context.
+func isContextFunc(funcType reflect.Type) bool {
+	return funcType.NumIn() > 0 && funcType.In(0) == contextType
+}
+
+// contextDictionary returns the dictionary of the registered locale that
+// best matches the locale carried by a context. The active dictionary is
+// returned if the context carries no locale.
+func (mf *MessageFactory) contextDictionary(ctx context.Context) *dictionary {
+	if ctx == nil {
+		return mf.activeDictionary()
+	}
+
+	tag, ok := LocaleFromContext(ctx)
+	if !ok {
+		return mf.activeDictionary()
+	}
+
+	return mf.localeDictionary(mf.resolveLocale(tag))
+}
+
+// localeDictionary returns the cached dictionary of a locale. The active
+// dictionary is returned if the locale is not registered.
+func (mf *MessageFactory) localeDictionary(tag language.Tag) *dictionary {
+	locale, ok := mf.locales[tag]
+	if !ok {
+		return mf.activeDictionary()
+	}
+
+	return mf.cachedDictionary(tag, locale)
+}
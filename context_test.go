@@ -0,0 +1,173 @@
+package g11n_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+func TestContextLocale(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(context.Context, int) string `default:"Cat %v"`
+	}
+
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`
+	{
+	  "M.MyLittleSomething": "Котка %v"
+	}
+`),
+		language.Spanish: TempFile(`
+	{
+	  "M.MyLittleSomething": "Gato %v"
+	}
+`),
+	}, "json")
+
+	m := factory.Init(&M{}).(*M)
+
+	ctx := context.Background()
+
+	testMessage(t,
+		m.MyLittleSomething(WithLocale(ctx, language.Bulgarian), 1),
+		"Котка 1")
+	testMessage(t,
+		m.MyLittleSomething(WithLocale(ctx, language.Spanish), 2),
+		"Gato 2")
+	testMessage(t,
+		m.MyLittleSomething(ctx, 3),
+		"Cat 3")
+}
+
+func TestContextLocaleFallback(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(context.Context, int) string `default:"Cat %v"`
+	}
+
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`
+	{
+	  "M.MyLittleSomething": "Котка %v"
+	}
+`),
+		language.Spanish: TempFile(`
+	{
+	  "M.MyLittleSomething": "Gato %v"
+	}
+`),
+	}, "json")
+
+	factory.SetDefaultLocale(language.Spanish)
+	factory.LoadLocale(language.Bulgarian)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething(WithLocale(context.Background(), language.Italian), 1),
+		"Gato 1")
+	testMessage(t,
+		m.MyLittleSomething(WithLocale(context.Background(), language.MustParse("es-MX")), 2),
+		"Gato 2")
+}
+
+func TestContextLocaleConcurrent(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(context.Context, int) string `default:"Cat %v"`
+	}
+
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`
+	{
+	  "M.MyLittleSomething": "Котка %v"
+	}
+`),
+		language.Spanish: TempFile(`
+	{
+	  "M.MyLittleSomething": "Gato %v"
+	}
+`),
+	}, "json")
+
+	m := factory.Init(&M{}).(*M)
+
+	expected := map[language.Tag]string{
+		language.Bulgarian: "Котка 42",
+		language.Spanish:   "Gato 42",
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for tag, message := range expected {
+			wg.Add(1)
+			go func(tag language.Tag, message string) {
+				defer wg.Done()
+
+				testMessage(t,
+					m.MyLittleSomething(WithLocale(context.Background(), tag), 42),
+					message)
+			}(tag, message)
+		}
+	}
+	wg.Wait()
+}
+
+func TestContextLocaleWhileLoading(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(context.Context, int) string `default:"Cat %v"`
+	}
+
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`
+	{
+	  "M.MyLittleSomething": "Котка %v"
+	}
+`),
+		language.Spanish: TempFile(`
+	{
+	  "M.MyLittleSomething": "Gato %v"
+	}
+`),
+	}, "json")
+
+	m := factory.Init(&M{}).(*M)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		for i := 0; i < 10; i++ {
+			factory.LoadLocale(language.Spanish)
+		}
+	}()
+
+	for i := 0; i < 10; i++ {
+		testMessage(t,
+			m.MyLittleSomething(WithLocale(context.Background(), language.Bulgarian), 42),
+			"Котка 42")
+	}
+	wg.Wait()
+}
+
+func TestLocaleFromContext(t *testing.T) {
+	ctx := WithLocale(context.Background(), language.Bulgarian)
+
+	if tag, ok := LocaleFromContext(ctx); !ok || tag != language.Bulgarian {
+		t.Errorf("Locale is not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", language.Bulgarian, tag)
+	}
+
+	if _, ok := LocaleFromContext(context.Background()); ok {
+		t.Errorf("Context without locale has a locale.")
+	}
+}
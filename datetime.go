@@ -0,0 +1,358 @@
+package g11n
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/text/feature/plural"
+	"golang.org/x/text/language"
+)
+
+// Style selects the length of a localized date or time.
+type Style int
+
+// Date and time styles.
+const (
+	MediumStyle Style = iota
+	ShortStyle
+	LongStyle
+	FullStyle
+)
+
+// styleNames maps the style names used in placeholders to styles.
+var styleNames = map[string]Style{
+	"medium": MediumStyle,
+	"short":  ShortStyle,
+	"long":   LongStyle,
+	"full":   FullStyle,
+}
+
+// Date is a message parameter that formats the date of a time in the active locale.
+type Date struct {
+	Time  time.Time
+	Style Style
+}
+
+// Time is a message parameter that formats the time of day in the active locale.
+type Time struct {
+	Time  time.Time
+	Style Style
+}
+
+// DateTime is a message parameter that formats the date and the time of day
+// in the active locale.
+type DateTime struct {
+	Time  time.Time
+	Style Style
+}
+
+// Relative is a message parameter that formats a time relative to Now in the
+// active locale, e.g. 3 days ago or in 2 hours. The current time is used if
+// Now is zero.
+type Relative struct {
+	Time time.Time
+	Now  time.Time
+}
+
+// timeUnit is a unit of relative times and durations.
+type timeUnit int
+
+// Time units.
+const (
+	second timeUnit = iota
+	minute
+	hour
+	day
+	week
+	month
+	year
+	unitsCount
+)
+
+// unitDurations holds the approximate length of each time unit.
+var unitDurations = [unitsCount]time.Duration{
+	second: time.Second,
+	minute: time.Minute,
+	hour:   time.Hour,
+	day:    24 * time.Hour,
+	week:   7 * 24 * time.Hour,
+	month:  30 * 24 * time.Hour,
+	year:   365 * 24 * time.Hour,
+}
+
+// placeholderFormatters format the arguments of date and time placeholders
+// in a style such as short or a skeleton such as ::yMMMd.
+var placeholderFormatters = map[string]func(tag language.Tag, value interface{}, style string) (string, bool){
+	"date": func(tag language.Tag, value interface{}, style string) (string, bool) {
+		t, ok := value.(time.Time)
+		calendar := calendarFor(tag)
+		dateLayout, _, _ := calendar.layouts(style)
+		return calendar.format(dateLayout, t), ok
+	},
+	"time": func(tag language.Tag, value interface{}, style string) (string, bool) {
+		t, ok := value.(time.Time)
+		calendar := calendarFor(tag)
+		_, timeLayout, _ := calendar.layouts(style)
+		return calendar.format(timeLayout, t), ok
+	},
+	"datetime": func(tag language.Tag, value interface{}, style string) (string, bool) {
+		t, ok := value.(time.Time)
+		calendar := calendarFor(tag)
+		dateLayout, timeLayout, glue := calendar.layouts(style)
+		return calendar.formatDateTime(t, dateLayout, timeLayout, glue), ok
+	},
+	"relative": func(tag language.Tag, value interface{}, style string) (string, bool) {
+		switch value := value.(type) {
+		case time.Time:
+			return formatRelative(tag, time.Until(value)), true
+		case time.Duration:
+			return formatRelative(tag, value), true
+		}
+		return "", false
+	},
+	"duration": func(tag language.Tag, value interface{}, style string) (string, bool) {
+		d, ok := value.(time.Duration)
+		return formatDuration(tag, d), ok
+	},
+}
+
+// calendarFor returns the calendar data of a locale.
+func calendarFor(tag language.Tag) *calendarData {
+	base, _ := tag.Base()
+	if calendar, ok := calendars[base.String()]; ok {
+		return calendar
+	}
+
+	return rootCalendar
+}
+
+// formatDate formats the date of a time in a locale.
+func formatDate(tag language.Tag, t time.Time, style Style) string {
+	calendar := calendarFor(tag)
+	return calendar.format(calendar.dateFormats[style], t)
+}
+
+// formatTime formats the time of day in a locale.
+func formatTime(tag language.Tag, t time.Time, style Style) string {
+	calendar := calendarFor(tag)
+	return calendar.format(calendar.timeFormats[style], t)
+}
+
+// formatDateTime formats the date and the time of day in a locale.
+func formatDateTime(tag language.Tag, t time.Time, style Style) string {
+	calendar := calendarFor(tag)
+	return calendar.formatDateTime(t,
+		calendar.dateFormats[style], calendar.timeFormats[style], calendar.dateTimeFormats[style])
+}
+
+// layouts returns the date layout, the time layout and the pattern that glues
+// them for a placeholder style. Skeletons are split in date and time fields
+// and unknown styles fall back to medium.
+func (cd *calendarData) layouts(style string) (string, string, string) {
+	if !strings.HasPrefix(style, "::") {
+		named := styleNames[style]
+		return cd.dateFormats[named], cd.timeFormats[named], cd.dateTimeFormats[named]
+	}
+
+	var dateSkeleton, timeSkeleton strings.Builder
+	for _, field := range style[2:] {
+		if strings.ContainsRune("HhKmsaz", field) {
+			timeSkeleton.WriteRune(field)
+		} else {
+			dateSkeleton.WriteRune(field)
+		}
+	}
+
+	return cd.skeleton(dateSkeleton.String(), cd.dateFormats[MediumStyle]),
+		cd.skeleton(timeSkeleton.String(), cd.timeFormats[MediumStyle]),
+		cd.dateTimeFormats[MediumStyle]
+}
+
+// skeleton returns the layout of a skeleton such as yMMMd, the layout of the
+// root calendar if the language has none or fallback for unknown skeletons.
+func (cd *calendarData) skeleton(skeleton string, fallback string) string {
+	if layout, ok := cd.skeletons[skeleton]; ok {
+		return layout
+	}
+	if layout, ok := rootCalendar.skeletons[skeleton]; ok {
+		return layout
+	}
+
+	return fallback
+}
+
+// formatDateTime formats a time with a date and a time layout glued by a
+// pattern that substitutes the date for {1} and the time for {0}. An empty
+// layout drops its part and the glue.
+func (cd *calendarData) formatDateTime(t time.Time, dateLayout, timeLayout, glue string) string {
+	if dateLayout == "" {
+		return cd.format(timeLayout, t)
+	}
+	if timeLayout == "" {
+		return cd.format(dateLayout, t)
+	}
+
+	glue = strings.Replace(glue, "'", "", -1)
+
+	var result strings.Builder
+	for i := 0; i < len(glue); i++ {
+		if i+2 < len(glue) && glue[i] == '{' && glue[i+2] == '}' {
+			switch glue[i+1] {
+			case '1':
+				result.WriteString(cd.format(dateLayout, t))
+				i += 2
+				continue
+			case '0':
+				result.WriteString(cd.format(timeLayout, t))
+				i += 2
+				continue
+			}
+		}
+		result.WriteByte(glue[i])
+	}
+
+	return result.String()
+}
+
+// formatRelative formats an offset from the current time in a locale.
+func formatRelative(tag language.Tag, offset time.Duration) string {
+	unit, count := splitDuration(offset)
+	data := calendarFor(tag).units[unit]
+
+	if offset < 0 {
+		return formatCount(data.past.pattern(tag, count), count)
+	}
+
+	return formatCount(data.future.pattern(tag, count), count)
+}
+
+// formatDuration formats a duration in its largest whole unit in a locale.
+func formatDuration(tag language.Tag, d time.Duration) string {
+	unit, count := splitDuration(d)
+	data := calendarFor(tag).units[unit]
+
+	return formatCount(data.duration.pattern(tag, count), count)
+}
+
+// splitDuration returns the largest unit of a duration and the number of
+// whole such units in it. The rest is truncated, e.g. 90 minutes is 1 hour,
+// so that a duration is never overstated.
+func splitDuration(d time.Duration) (timeUnit, int) {
+	if d < 0 {
+		d = -d
+	}
+
+	unit := second
+	for candidate := minute; candidate < unitsCount; candidate++ {
+		if d >= unitDurations[candidate] {
+			unit = candidate
+		}
+	}
+
+	return unit, int(d / unitDurations[unit])
+}
+
+// pattern selects the pattern of the plural form of a count in a locale.
+// Plural rules are matched by the language base, as calendars are.
+func (p *pluralPatterns) pattern(tag language.Tag, count int) string {
+	base, _ := tag.Base()
+	baseTag, _ := language.Compose(base)
+
+	if pattern := p[plural.Cardinal.MatchPlural(baseTag, count, 0, 0, 0, 0)]; pattern != "" {
+		return pattern
+	}
+
+	return p[plural.Other]
+}
+
+// formatCount substitutes a count in a unit pattern.
+func formatCount(unitPattern string, count int) string {
+	return strings.Replace(unitPattern, "{0}", strconv.Itoa(count), 1)
+}
+
+// format formats a time according to a CLDR date format pattern.
+func (cd *calendarData) format(layout string, t time.Time) string {
+	var result strings.Builder
+
+	for i := 0; i < len(layout); {
+		c := layout[i]
+
+		// Quoted literal text.
+		if c == '\'' {
+			end := strings.IndexByte(layout[i+1:], '\'')
+			if end < 0 {
+				result.WriteString(layout[i+1:])
+				break
+			}
+			result.WriteString(layout[i+1 : i+1+end])
+			i += end + 2
+			continue
+		}
+
+		// Repeated pattern letter.
+		count := 1
+		for i+count < len(layout) && layout[i+count] == c {
+			count++
+		}
+
+		result.WriteString(cd.formatField(c, count, t, layout[i:i+count]))
+		i += count
+	}
+
+	return result.String()
+}
+
+// formatField formats a single CLDR pattern field such as MMM.
+func (cd *calendarData) formatField(field byte, count int, t time.Time, literal string) string {
+	switch field {
+	case 'y':
+		if count == 2 {
+			return pad(t.Year()%100, 2)
+		}
+		return strconv.Itoa(t.Year())
+	case 'M':
+		switch {
+		case count >= 4:
+			return cd.months[t.Month()-1]
+		case count == 3:
+			return cd.monthsAbbr[t.Month()-1]
+		}
+		return pad(int(t.Month()), count)
+	case 'd':
+		return pad(t.Day(), count)
+	case 'E':
+		return cd.weekdays[t.Weekday()]
+	case 'H':
+		return pad(t.Hour(), count)
+	case 'h':
+		hour := t.Hour() % 12
+		if hour == 0 {
+			hour = 12
+		}
+		return pad(hour, count)
+	case 'K':
+		return pad(t.Hour()%12, count)
+	case 'm':
+		return pad(t.Minute(), count)
+	case 's':
+		return pad(t.Second(), count)
+	case 'a':
+		return cd.dayPeriods[t.Hour()/12]
+	case 'z':
+		zone, _ := t.Zone()
+		return zone
+	}
+
+	return literal
+}
+
+// pad formats a number with at least width digits.
+func pad(value, width int) string {
+	result := strconv.Itoa(value)
+	for len(result) < width {
+		result = "0" + result
+	}
+
+	return result
+}
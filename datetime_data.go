@@ -0,0 +1,318 @@
+package g11n
+
+import "golang.org/x/text/feature/plural"
+
+// calendarData holds the CLDR calendar and relative time data of a language.
+type calendarData struct {
+	months          [12]string
+	monthsAbbr      [12]string
+	weekdays        [7]string
+	dayPeriods      [2]string
+	dateFormats     [4]string
+	timeFormats     [4]string
+	dateTimeFormats [4]string
+	skeletons       map[string]string
+	units           [unitsCount]unitData
+}
+
+// unitData holds the patterns of a time unit by plural form.
+type unitData struct {
+	future   pluralPatterns
+	past     pluralPatterns
+	duration pluralPatterns
+}
+
+// pluralPatterns holds a pattern for each plural form. Missing forms fall
+// back to the other form.
+type pluralPatterns [plural.Many + 1]string
+
+// oneOther returns the patterns of a language with one and other plural forms.
+func oneOther(one, other string) pluralPatterns {
+	return pluralPatterns{plural.One: one, plural.Other: other}
+}
+
+// oneFewManyOther returns the patterns of a language with one, few, many and
+// other plural forms.
+func oneFewManyOther(one, few, many, other string) pluralPatterns {
+	return pluralPatterns{plural.One: one, plural.Few: few, plural.Many: many, plural.Other: other}
+}
+
+// rootCalendar is the CLDR root data used for languages without calendar data.
+var rootCalendar = &calendarData{
+	months: [12]string{"M01", "M02", "M03", "M04", "M05", "M06",
+		"M07", "M08", "M09", "M10", "M11", "M12"},
+	monthsAbbr: [12]string{"M01", "M02", "M03", "M04", "M05", "M06",
+		"M07", "M08", "M09", "M10", "M11", "M12"},
+	weekdays:        [7]string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"},
+	dayPeriods:      [2]string{"AM", "PM"},
+	dateFormats:     [4]string{"y MMM d", "y-MM-dd", "y MMMM d", "y MMMM d, EEEE"},
+	timeFormats:     [4]string{"HH:mm:ss", "HH:mm", "HH:mm:ss z", "HH:mm:ss z"},
+	dateTimeFormats: [4]string{"{1} {0}", "{1} {0}", "{1} {0}", "{1} {0}"},
+	skeletons: map[string]string{
+		"Md": "MM-dd", "MMMd": "MMM d", "MMMMd": "MMMM d", "yM": "y-MM", "yMd": "y-MM-dd", "yMMMd": "y MMM d",
+		"Hm": "HH:mm", "Hms": "HH:mm:ss", "hm": "h:mm a", "hms": "h:mm:ss a",
+	},
+	units: [unitsCount]unitData{
+		second: {pluralPatterns{plural.Other: "+{0} s"}, pluralPatterns{plural.Other: "-{0} s"}, pluralPatterns{plural.Other: "{0} s"}},
+		minute: {pluralPatterns{plural.Other: "+{0} min"}, pluralPatterns{plural.Other: "-{0} min"}, pluralPatterns{plural.Other: "{0} min"}},
+		hour:   {pluralPatterns{plural.Other: "+{0} h"}, pluralPatterns{plural.Other: "-{0} h"}, pluralPatterns{plural.Other: "{0} h"}},
+		day:    {pluralPatterns{plural.Other: "+{0} d"}, pluralPatterns{plural.Other: "-{0} d"}, pluralPatterns{plural.Other: "{0} d"}},
+		week:   {pluralPatterns{plural.Other: "+{0} w"}, pluralPatterns{plural.Other: "-{0} w"}, pluralPatterns{plural.Other: "{0} w"}},
+		month:  {pluralPatterns{plural.Other: "+{0} m"}, pluralPatterns{plural.Other: "-{0} m"}, pluralPatterns{plural.Other: "{0} m"}},
+		year:   {pluralPatterns{plural.Other: "+{0} y"}, pluralPatterns{plural.Other: "-{0} y"}, pluralPatterns{plural.Other: "{0} y"}},
+	},
+}
+
+// calendars maps language bases to their calendar data. The formats are
+// ordered by style: medium, short, long, full. Skeletons missing from a
+// language fall back to the root calendar.
+var calendars = map[string]*calendarData{
+	"en": {
+		months: [12]string{"January", "February", "March", "April", "May", "June",
+			"July", "August", "September", "October", "November", "December"},
+		monthsAbbr: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun",
+			"Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		weekdays:        [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		dayPeriods:      [2]string{"AM", "PM"},
+		dateFormats:     [4]string{"MMM d, y", "M/d/yy", "MMMM d, y", "EEEE, MMMM d, y"},
+		timeFormats:     [4]string{"h:mm:ss a", "h:mm a", "h:mm:ss a z", "h:mm:ss a z"},
+		dateTimeFormats: [4]string{"{1}, {0}", "{1}, {0}", "{1} 'at' {0}", "{1} 'at' {0}"},
+		skeletons: map[string]string{
+			"Md": "M/d", "MMMd": "MMM d", "MMMMd": "MMMM d", "yM": "M/y", "yMd": "M/d/y", "yMMMd": "MMM d, y",
+			"Hm": "HH:mm", "Hms": "HH:mm:ss", "hm": "h:mm a", "hms": "h:mm:ss a",
+		},
+		units: [unitsCount]unitData{
+			second: {oneOther("in {0} second", "in {0} seconds"), oneOther("{0} second ago", "{0} seconds ago"), oneOther("{0} second", "{0} seconds")},
+			minute: {oneOther("in {0} minute", "in {0} minutes"), oneOther("{0} minute ago", "{0} minutes ago"), oneOther("{0} minute", "{0} minutes")},
+			hour:   {oneOther("in {0} hour", "in {0} hours"), oneOther("{0} hour ago", "{0} hours ago"), oneOther("{0} hour", "{0} hours")},
+			day:    {oneOther("in {0} day", "in {0} days"), oneOther("{0} day ago", "{0} days ago"), oneOther("{0} day", "{0} days")},
+			week:   {oneOther("in {0} week", "in {0} weeks"), oneOther("{0} week ago", "{0} weeks ago"), oneOther("{0} week", "{0} weeks")},
+			month:  {oneOther("in {0} month", "in {0} months"), oneOther("{0} month ago", "{0} months ago"), oneOther("{0} month", "{0} months")},
+			year:   {oneOther("in {0} year", "in {0} years"), oneOther("{0} year ago", "{0} years ago"), oneOther("{0} year", "{0} years")},
+		},
+	},
+	"bg": {
+		months: [12]string{"януари", "февруари", "март", "април", "май", "юни",
+			"юли", "август", "септември", "октомври", "ноември", "декември"},
+		monthsAbbr: [12]string{"яну", "фев", "март", "апр", "май", "юни",
+			"юли", "авг", "сеп", "окт", "ное", "дек"},
+		weekdays:        [7]string{"неделя", "понеделник", "вторник", "сряда", "четвъртък", "петък", "събота"},
+		dayPeriods:      [2]string{"пр.об.", "сл.об."},
+		dateFormats:     [4]string{"d.MM.y 'г'.", "d.MM.yy 'г'.", "d MMMM y 'г'.", "EEEE, d MMMM y 'г'."},
+		timeFormats:     [4]string{"H:mm:ss 'ч'.", "H:mm 'ч'.", "H:mm:ss 'ч'. z", "H:mm:ss 'ч'. z"},
+		dateTimeFormats: [4]string{"{1}, {0}", "{1}, {0}", "{1}, {0}", "{1}, {0}"},
+		skeletons: map[string]string{
+			"Md": "d.MM", "MMMd": "d.MM", "MMMMd": "d MMMM", "yM": "M.y 'г'.", "yMd": "d.MM.y 'г'.", "yMMMd": "d.MM.y 'г'.",
+			"Hm": "H:mm 'ч'.", "Hms": "H:mm:ss 'ч'.", "hm": "h:mm 'ч'. a", "hms": "h:mm:ss 'ч'. a",
+		},
+		units: [unitsCount]unitData{
+			second: {oneOther("след {0} секунда", "след {0} секунди"), oneOther("преди {0} секунда", "преди {0} секунди"), oneOther("{0} секунда", "{0} секунди")},
+			minute: {oneOther("след {0} минута", "след {0} минути"), oneOther("преди {0} минута", "преди {0} минути"), oneOther("{0} минута", "{0} минути")},
+			hour:   {oneOther("след {0} час", "след {0} часа"), oneOther("преди {0} час", "преди {0} часа"), oneOther("{0} час", "{0} часа")},
+			day:    {oneOther("след {0} ден", "след {0} дни"), oneOther("преди {0} ден", "преди {0} дни"), oneOther("{0} ден", "{0} дни")},
+			week:   {oneOther("след {0} седмица", "след {0} седмици"), oneOther("преди {0} седмица", "преди {0} седмици"), oneOther("{0} седмица", "{0} седмици")},
+			month:  {oneOther("след {0} месец", "след {0} месеца"), oneOther("преди {0} месец", "преди {0} месеца"), oneOther("{0} месец", "{0} месеца")},
+			year:   {oneOther("след {0} година", "след {0} години"), oneOther("преди {0} година", "преди {0} години"), oneOther("{0} година", "{0} години")},
+		},
+	},
+	"de": {
+		months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni",
+			"Juli", "August", "September", "Oktober", "November", "Dezember"},
+		monthsAbbr: [12]string{"Jan.", "Feb.", "März", "Apr.", "Mai", "Juni",
+			"Juli", "Aug.", "Sept.", "Okt.", "Nov.", "Dez."},
+		weekdays:        [7]string{"Sonntag", "Montag", "Dienstag", "Mittwoch", "Donnerstag", "Freitag", "Samstag"},
+		dayPeriods:      [2]string{"AM", "PM"},
+		dateFormats:     [4]string{"dd.MM.y", "dd.MM.yy", "d. MMMM y", "EEEE, d. MMMM y"},
+		timeFormats:     [4]string{"HH:mm:ss", "HH:mm", "HH:mm:ss z", "HH:mm:ss z"},
+		dateTimeFormats: [4]string{"{1}, {0}", "{1}, {0}", "{1} 'um' {0}", "{1} 'um' {0}"},
+		skeletons: map[string]string{
+			"Md": "d.M.", "MMMd": "d. MMM", "MMMMd": "d. MMMM", "yM": "M/y", "yMd": "d.M.y", "yMMMd": "d. MMM y",
+			"Hm": "HH:mm", "Hms": "HH:mm:ss", "hm": "h:mm a", "hms": "h:mm:ss a",
+		},
+		units: [unitsCount]unitData{
+			second: {oneOther("in {0} Sekunde", "in {0} Sekunden"), oneOther("vor {0} Sekunde", "vor {0} Sekunden"), oneOther("{0} Sekunde", "{0} Sekunden")},
+			minute: {oneOther("in {0} Minute", "in {0} Minuten"), oneOther("vor {0} Minute", "vor {0} Minuten"), oneOther("{0} Minute", "{0} Minuten")},
+			hour:   {oneOther("in {0} Stunde", "in {0} Stunden"), oneOther("vor {0} Stunde", "vor {0} Stunden"), oneOther("{0} Stunde", "{0} Stunden")},
+			day:    {oneOther("in {0} Tag", "in {0} Tagen"), oneOther("vor {0} Tag", "vor {0} Tagen"), oneOther("{0} Tag", "{0} Tage")},
+			week:   {oneOther("in {0} Woche", "in {0} Wochen"), oneOther("vor {0} Woche", "vor {0} Wochen"), oneOther("{0} Woche", "{0} Wochen")},
+			month:  {oneOther("in {0} Monat", "in {0} Monaten"), oneOther("vor {0} Monat", "vor {0} Monaten"), oneOther("{0} Monat", "{0} Monate")},
+			year:   {oneOther("in {0} Jahr", "in {0} Jahren"), oneOther("vor {0} Jahr", "vor {0} Jahren"), oneOther("{0} Jahr", "{0} Jahre")},
+		},
+	},
+	"es": {
+		months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio",
+			"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		monthsAbbr: [12]string{"ene", "feb", "mar", "abr", "may", "jun",
+			"jul", "ago", "sept", "oct", "nov", "dic"},
+		weekdays:        [7]string{"domingo", "lunes", "martes", "miércoles", "jueves", "viernes", "sábado"},
+		dayPeriods:      [2]string{"a. m.", "p. m."},
+		dateFormats:     [4]string{"d MMM y", "d/M/yy", "d 'de' MMMM 'de' y", "EEEE, d 'de' MMMM 'de' y"},
+		timeFormats:     [4]string{"H:mm:ss", "H:mm", "H:mm:ss z", "H:mm:ss z"},
+		dateTimeFormats: [4]string{"{1}, {0}", "{1}, {0}", "{1}, {0}", "{1}, {0}"},
+		skeletons: map[string]string{
+			"Md": "d/M", "MMMd": "d MMM", "MMMMd": "d 'de' MMMM", "yM": "M/y", "yMd": "d/M/y", "yMMMd": "d MMM y",
+			"Hm": "H:mm", "Hms": "H:mm:ss", "hm": "h:mm a", "hms": "h:mm:ss a",
+		},
+		units: [unitsCount]unitData{
+			second: {oneOther("dentro de {0} segundo", "dentro de {0} segundos"), oneOther("hace {0} segundo", "hace {0} segundos"), oneOther("{0} segundo", "{0} segundos")},
+			minute: {oneOther("dentro de {0} minuto", "dentro de {0} minutos"), oneOther("hace {0} minuto", "hace {0} minutos"), oneOther("{0} minuto", "{0} minutos")},
+			hour:   {oneOther("dentro de {0} hora", "dentro de {0} horas"), oneOther("hace {0} hora", "hace {0} horas"), oneOther("{0} hora", "{0} horas")},
+			day:    {oneOther("dentro de {0} día", "dentro de {0} días"), oneOther("hace {0} día", "hace {0} días"), oneOther("{0} día", "{0} días")},
+			week:   {oneOther("dentro de {0} semana", "dentro de {0} semanas"), oneOther("hace {0} semana", "hace {0} semanas"), oneOther("{0} semana", "{0} semanas")},
+			month:  {oneOther("dentro de {0} mes", "dentro de {0} meses"), oneOther("hace {0} mes", "hace {0} meses"), oneOther("{0} mes", "{0} meses")},
+			year:   {oneOther("dentro de {0} año", "dentro de {0} años"), oneOther("hace {0} año", "hace {0} años"), oneOther("{0} año", "{0} años")},
+		},
+	},
+	"fr": {
+		months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin",
+			"juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		monthsAbbr: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin",
+			"juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		weekdays:        [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		dayPeriods:      [2]string{"AM", "PM"},
+		dateFormats:     [4]string{"d MMM y", "dd/MM/y", "d MMMM y", "EEEE d MMMM y"},
+		timeFormats:     [4]string{"HH:mm:ss", "HH:mm", "HH:mm:ss z", "HH:mm:ss z"},
+		dateTimeFormats: [4]string{"{1} {0}", "{1} {0}", "{1} 'à' {0}", "{1} 'à' {0}"},
+		skeletons: map[string]string{
+			"Md": "dd/MM", "MMMd": "d MMM", "MMMMd": "d MMMM", "yM": "MM/y", "yMd": "dd/MM/y", "yMMMd": "d MMM y",
+			"Hm": "HH:mm", "Hms": "HH:mm:ss", "hm": "h:mm a", "hms": "h:mm:ss a",
+		},
+		units: [unitsCount]unitData{
+			second: {oneOther("dans {0} seconde", "dans {0} secondes"), oneOther("il y a {0} seconde", "il y a {0} secondes"), oneOther("{0} seconde", "{0} secondes")},
+			minute: {oneOther("dans {0} minute", "dans {0} minutes"), oneOther("il y a {0} minute", "il y a {0} minutes"), oneOther("{0} minute", "{0} minutes")},
+			hour:   {oneOther("dans {0} heure", "dans {0} heures"), oneOther("il y a {0} heure", "il y a {0} heures"), oneOther("{0} heure", "{0} heures")},
+			day:    {oneOther("dans {0} jour", "dans {0} jours"), oneOther("il y a {0} jour", "il y a {0} jours"), oneOther("{0} jour", "{0} jours")},
+			week:   {oneOther("dans {0} semaine", "dans {0} semaines"), oneOther("il y a {0} semaine", "il y a {0} semaines"), oneOther("{0} semaine", "{0} semaines")},
+			month:  {oneOther("dans {0} mois", "dans {0} mois"), oneOther("il y a {0} mois", "il y a {0} mois"), oneOther("{0} mois", "{0} mois")},
+			year:   {oneOther("dans {0} an", "dans {0} ans"), oneOther("il y a {0} an", "il y a {0} ans"), oneOther("{0} an", "{0} ans")},
+		},
+	},
+	"ja": {
+		months: [12]string{"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月"},
+		monthsAbbr: [12]string{"1月", "2月", "3月", "4月", "5月", "6月",
+			"7月", "8月", "9月", "10月", "11月", "12月"},
+		weekdays:        [7]string{"日曜日", "月曜日", "火曜日", "水曜日", "木曜日", "金曜日", "土曜日"},
+		dayPeriods:      [2]string{"午前", "午後"},
+		dateFormats:     [4]string{"y/MM/dd", "y/MM/dd", "y年M月d日", "y年M月d日EEEE"},
+		timeFormats:     [4]string{"H:mm:ss", "H:mm", "H:mm:ss z", "H時mm分ss秒 z"},
+		dateTimeFormats: [4]string{"{1} {0}", "{1} {0}", "{1} {0}", "{1} {0}"},
+		skeletons: map[string]string{
+			"Md": "M/d", "MMMd": "M月d日", "MMMMd": "M月d日", "yM": "y/M", "yMd": "y/M/d", "yMMMd": "y年M月d日",
+			"Hm": "H:mm", "Hms": "H:mm:ss", "hm": "aK:mm", "hms": "aK:mm:ss",
+		},
+		units: [unitsCount]unitData{
+			second: {pluralPatterns{plural.Other: "{0} 秒後"}, pluralPatterns{plural.Other: "{0} 秒前"}, pluralPatterns{plural.Other: "{0} 秒"}},
+			minute: {pluralPatterns{plural.Other: "{0} 分後"}, pluralPatterns{plural.Other: "{0} 分前"}, pluralPatterns{plural.Other: "{0} 分"}},
+			hour:   {pluralPatterns{plural.Other: "{0} 時間後"}, pluralPatterns{plural.Other: "{0} 時間前"}, pluralPatterns{plural.Other: "{0} 時間"}},
+			day:    {pluralPatterns{plural.Other: "{0} 日後"}, pluralPatterns{plural.Other: "{0} 日前"}, pluralPatterns{plural.Other: "{0} 日"}},
+			week:   {pluralPatterns{plural.Other: "{0} 週間後"}, pluralPatterns{plural.Other: "{0} 週間前"}, pluralPatterns{plural.Other: "{0} 週間"}},
+			month:  {pluralPatterns{plural.Other: "{0} か月後"}, pluralPatterns{plural.Other: "{0} か月前"}, pluralPatterns{plural.Other: "{0} か月"}},
+			year:   {pluralPatterns{plural.Other: "{0} 年後"}, pluralPatterns{plural.Other: "{0} 年前"}, pluralPatterns{plural.Other: "{0} 年"}},
+		},
+	},
+	"pl": {
+		months: [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca",
+			"lipca", "sierpnia", "września", "października", "listopada", "grudnia"},
+		monthsAbbr: [12]string{"sty", "lut", "mar", "kwi", "maj", "cze",
+			"lip", "sie", "wrz", "paź", "lis", "gru"},
+		weekdays:        [7]string{"niedziela", "poniedziałek", "wtorek", "środa", "czwartek", "piątek", "sobota"},
+		dayPeriods:      [2]string{"AM", "PM"},
+		dateFormats:     [4]string{"d MMM y", "d.MM.y", "d MMMM y", "EEEE, d MMMM y"},
+		timeFormats:     [4]string{"HH:mm:ss", "HH:mm", "HH:mm:ss z", "HH:mm:ss z"},
+		dateTimeFormats: [4]string{"{1}, {0}", "{1}, {0}", "{1} {0}", "{1} {0}"},
+		skeletons: map[string]string{
+			"Md": "d.MM", "MMMd": "d MMM", "MMMMd": "d MMMM", "yM": "MM.y", "yMd": "d.MM.y", "yMMMd": "d MMM y",
+			"Hm": "HH:mm", "Hms": "HH:mm:ss", "hm": "h:mm a", "hms": "h:mm:ss a",
+		},
+		units: [unitsCount]unitData{
+			second: {
+				oneFewManyOther("za {0} sekundę", "za {0} sekundy", "za {0} sekund", "za {0} sekundy"),
+				oneFewManyOther("{0} sekundę temu", "{0} sekundy temu", "{0} sekund temu", "{0} sekundy temu"),
+				oneFewManyOther("{0} sekunda", "{0} sekundy", "{0} sekund", "{0} sekundy"),
+			},
+			minute: {
+				oneFewManyOther("za {0} minutę", "za {0} minuty", "za {0} minut", "za {0} minuty"),
+				oneFewManyOther("{0} minutę temu", "{0} minuty temu", "{0} minut temu", "{0} minuty temu"),
+				oneFewManyOther("{0} minuta", "{0} minuty", "{0} minut", "{0} minuty"),
+			},
+			hour: {
+				oneFewManyOther("za {0} godzinę", "za {0} godziny", "za {0} godzin", "za {0} godziny"),
+				oneFewManyOther("{0} godzinę temu", "{0} godziny temu", "{0} godzin temu", "{0} godziny temu"),
+				oneFewManyOther("{0} godzina", "{0} godziny", "{0} godzin", "{0} godziny"),
+			},
+			day: {
+				oneFewManyOther("za {0} dzień", "za {0} dni", "za {0} dni", "za {0} dnia"),
+				oneFewManyOther("{0} dzień temu", "{0} dni temu", "{0} dni temu", "{0} dnia temu"),
+				oneFewManyOther("{0} dzień", "{0} dni", "{0} dni", "{0} dnia"),
+			},
+			week: {
+				oneFewManyOther("za {0} tydzień", "za {0} tygodnie", "za {0} tygodni", "za {0} tygodnia"),
+				oneFewManyOther("{0} tydzień temu", "{0} tygodnie temu", "{0} tygodni temu", "{0} tygodnia temu"),
+				oneFewManyOther("{0} tydzień", "{0} tygodnie", "{0} tygodni", "{0} tygodnia"),
+			},
+			month: {
+				oneFewManyOther("za {0} miesiąc", "za {0} miesiące", "za {0} miesięcy", "za {0} miesiąca"),
+				oneFewManyOther("{0} miesiąc temu", "{0} miesiące temu", "{0} miesięcy temu", "{0} miesiąca temu"),
+				oneFewManyOther("{0} miesiąc", "{0} miesiące", "{0} miesięcy", "{0} miesiąca"),
+			},
+			year: {
+				oneFewManyOther("za {0} rok", "za {0} lata", "za {0} lat", "za {0} roku"),
+				oneFewManyOther("{0} rok temu", "{0} lata temu", "{0} lat temu", "{0} roku temu"),
+				oneFewManyOther("{0} rok", "{0} lata", "{0} lat", "{0} roku"),
+			},
+		},
+	},
+	"ru": {
+		months: [12]string{"января", "февраля", "марта", "апреля", "мая", "июня",
+			"июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		monthsAbbr: [12]string{"янв.", "февр.", "мар.", "апр.", "мая", "июн.",
+			"июл.", "авг.", "сент.", "окт.", "нояб.", "дек."},
+		weekdays:        [7]string{"воскресенье", "понедельник", "вторник", "среда", "четверг", "пятница", "суббота"},
+		dayPeriods:      [2]string{"AM", "PM"},
+		dateFormats:     [4]string{"d MMM y 'г'.", "dd.MM.y", "d MMMM y 'г'.", "EEEE, d MMMM y 'г'."},
+		timeFormats:     [4]string{"HH:mm:ss", "HH:mm", "HH:mm:ss z", "HH:mm:ss z"},
+		dateTimeFormats: [4]string{"{1}, {0}", "{1}, {0}", "{1}, {0}", "{1}, {0}"},
+		skeletons: map[string]string{
+			"Md": "dd.MM", "MMMd": "d MMM", "MMMMd": "d MMMM", "yM": "MM.y", "yMd": "dd.MM.y", "yMMMd": "d MMM y 'г'.",
+			"Hm": "HH:mm", "Hms": "HH:mm:ss", "hm": "h:mm a", "hms": "h:mm:ss a",
+		},
+		units: [unitsCount]unitData{
+			second: {
+				oneFewManyOther("через {0} секунду", "через {0} секунды", "через {0} секунд", "через {0} секунды"),
+				oneFewManyOther("{0} секунду назад", "{0} секунды назад", "{0} секунд назад", "{0} секунды назад"),
+				oneFewManyOther("{0} секунда", "{0} секунды", "{0} секунд", "{0} секунды"),
+			},
+			minute: {
+				oneFewManyOther("через {0} минуту", "через {0} минуты", "через {0} минут", "через {0} минуты"),
+				oneFewManyOther("{0} минуту назад", "{0} минуты назад", "{0} минут назад", "{0} минуты назад"),
+				oneFewManyOther("{0} минута", "{0} минуты", "{0} минут", "{0} минуты"),
+			},
+			hour: {
+				oneFewManyOther("через {0} час", "через {0} часа", "через {0} часов", "через {0} часа"),
+				oneFewManyOther("{0} час назад", "{0} часа назад", "{0} часов назад", "{0} часа назад"),
+				oneFewManyOther("{0} час", "{0} часа", "{0} часов", "{0} часа"),
+			},
+			day: {
+				oneFewManyOther("через {0} день", "через {0} дня", "через {0} дней", "через {0} дня"),
+				oneFewManyOther("{0} день назад", "{0} дня назад", "{0} дней назад", "{0} дня назад"),
+				oneFewManyOther("{0} день", "{0} дня", "{0} дней", "{0} дня"),
+			},
+			week: {
+				oneFewManyOther("через {0} неделю", "через {0} недели", "через {0} недель", "через {0} недели"),
+				oneFewManyOther("{0} неделю назад", "{0} недели назад", "{0} недель назад", "{0} недели назад"),
+				oneFewManyOther("{0} неделя", "{0} недели", "{0} недель", "{0} недели"),
+			},
+			month: {
+				oneFewManyOther("через {0} месяц", "через {0} месяца", "через {0} месяцев", "через {0} месяца"),
+				oneFewManyOther("{0} месяц назад", "{0} месяца назад", "{0} месяцев назад", "{0} месяца назад"),
+				oneFewManyOther("{0} месяц", "{0} месяца", "{0} месяцев", "{0} месяца"),
+			},
+			year: {
+				oneFewManyOther("через {0} год", "через {0} года", "через {0} лет", "через {0} года"),
+				oneFewManyOther("{0} год назад", "{0} года назад", "{0} лет назад", "{0} года назад"),
+				oneFewManyOther("{0} год", "{0} года", "{0} лет", "{0} года"),
+			},
+		},
+	},
+}
@@ -0,0 +1,218 @@
+package g11n_test
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+var testTime = time.Date(2015, time.March, 7, 16, 5, 9, 0, time.UTC)
+
+func TestDateParams(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(Date, Date, Time, DateTime) string `default:"%v | %v | %v | %v"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.English, "json", TempFile(`{}`))
+	factory.SetLocale(language.German, "json", TempFile(`{}`))
+
+	m := factory.Init(&M{}).(*M)
+
+	factory.LoadLocale(language.English)
+	testMessage(t,
+		m.MyLittleSomething(
+			Date{Time: testTime},
+			Date{Time: testTime, Style: FullStyle},
+			Time{Time: testTime, Style: ShortStyle},
+			DateTime{Time: testTime, Style: LongStyle}),
+		"Mar 7, 2015 | Saturday, March 7, 2015 | 4:05 PM | March 7, 2015 at 4:05:09 PM UTC")
+
+	factory.LoadLocale(language.German)
+	testMessage(t,
+		m.MyLittleSomething(
+			Date{Time: testTime},
+			Date{Time: testTime, Style: FullStyle},
+			Time{Time: testTime, Style: ShortStyle},
+			DateTime{Time: testTime, Style: LongStyle}),
+		"07.03.2015 | Samstag, 7. März 2015 | 16:05 | 7. März 2015 um 16:05:09 UTC")
+}
+
+func TestTimeParam(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(time.Time) string `default:"Sent %v"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`{}`))
+
+	factory.LoadLocale(language.Bulgarian)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething(testTime),
+		"Sent 7.03.2015 г., 16:05:09 ч.")
+}
+
+func TestDatePlaceholders(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(string, time.Time) string `default:"%v on {2, date, short} at {2, time, short}"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.French, "json", TempFile(`{}`))
+
+	factory.LoadLocale(language.French)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething("Gopher", testTime),
+		"Gopher on 07/03/2015 at 16:05")
+}
+
+func TestDurationParams(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(time.Duration, time.Duration) string `default:"{1, relative}, {2, relative}, %v"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.English, "json", TempFile(`{}`))
+	factory.SetLocale(language.Spanish, "json", TempFile(`{}`))
+
+	m := factory.Init(&M{}).(*M)
+
+	factory.LoadLocale(language.English)
+	testMessage(t,
+		m.MyLittleSomething(-72*time.Hour, 2*time.Hour),
+		"3 days ago, in 2 hours, 3 days")
+
+	factory.LoadLocale(language.Spanish)
+	testMessage(t,
+		m.MyLittleSomething(-time.Minute, 90*time.Second),
+		"hace 1 minuto, dentro de 1 minuto, 1 minuto")
+}
+
+func TestDuration(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(time.Duration) string `default:"Took %v"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.German, "json", TempFile(`{}`))
+
+	factory.LoadLocale(language.German)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething(49*time.Hour),
+		"Took 2 Tage")
+}
+
+func TestRelativeParam(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(Relative) string `default:"Updated %v"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`{}`))
+
+	factory.LoadLocale(language.Bulgarian)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething(Relative{Time: testTime, Now: testTime.Add(21 * 24 * time.Hour)}),
+		"Updated преди 3 седмици")
+}
+
+func TestRelativePluralForms(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(time.Duration, time.Duration, time.Duration) string `default:"%v, %v, %v"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.Russian, "json", TempFile(`{}`))
+	factory.SetLocale(language.Polish, "json", TempFile(`{}`))
+
+	m := factory.Init(&M{}).(*M)
+
+	factory.LoadLocale(language.Russian)
+	testMessage(t,
+		m.MyLittleSomething(2*time.Hour, 5*time.Hour, 21*time.Hour),
+		"2 часа, 5 часов, 21 час")
+
+	factory.LoadLocale(language.Polish)
+	testMessage(t,
+		m.MyLittleSomething(2*time.Hour, 5*time.Hour, 21*time.Hour),
+		"2 godziny, 5 godzin, 21 godzin")
+}
+
+func TestDurationTruncation(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(time.Duration, time.Duration) string `default:"{1, relative}, %[2]v"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.English, "json", TempFile(`{}`))
+	factory.SetLocale(language.German, "json", TempFile(`{}`))
+
+	m := factory.Init(&M{}).(*M)
+
+	factory.LoadLocale(language.English)
+	testMessage(t,
+		m.MyLittleSomething(59*time.Minute+36*time.Second, 90*time.Minute),
+		"in 59 minutes, 1 hour")
+
+	factory.LoadLocale(language.German)
+	testMessage(t,
+		m.MyLittleSomething(-90*time.Minute, 47*time.Hour),
+		"vor 1 Stunde, 1 Tag")
+}
+
+func TestDateSkeletons(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(time.Time) string `default:"{1, date, ::yMMMd} | {1, time, ::hm} | {1, datetime, ::MMMMdHm} | {1, date, ::Gy}"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.English, "json", TempFile(`{}`))
+	factory.SetLocale(language.Japanese, "json", TempFile(`{}`))
+
+	m := factory.Init(&M{}).(*M)
+
+	factory.LoadLocale(language.English)
+	testMessage(t,
+		m.MyLittleSomething(testTime),
+		"Mar 7, 2015 | 4:05 PM | March 7, 16:05 | Mar 7, 2015")
+
+	factory.LoadLocale(language.Japanese)
+	testMessage(t,
+		m.MyLittleSomething(testTime),
+		"2015年3月7日 | 午後4:05 | 3月7日 16:05 | 2015/03/07")
+}
+
+func TestRootCalendar(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(Date, time.Duration) string `default:"%v, {2, relative}"`
+	}
+
+	klingon := language.MustParse("tlh")
+
+	factory := New()
+	factory.SetLocale(klingon, "json", TempFile(`{}`))
+
+	factory.LoadLocale(klingon)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething(Date{Time: testTime, Style: LongStyle}, -3*time.Hour),
+		"2015 M03 7, -3 h")
+}
@@ -41,6 +41,13 @@
 //
 //	G.SetLocale("en")
 //
+// The locale that best matches a list of preferred locales could be found with
+// MatchLocale. The default locale is returned when none of the registered locales
+// matches.
+//
+//	G.SetDefaultLocale(language.English)
+//	tag, confidence := G.MatchLocale(preferred...)
+//
 //
 // III. Format parameters
 //
@@ -68,6 +75,24 @@
 //		MyLittleSomething func(PluralFormat) string `default:"Count: %v"`
 //	}
 //
+// A parameter that is formatted differently in each locale could implement
+//
+//	G11nParamLocale(tag language.Tag) string
+//
+// instead, which receives the locale of the message.
+//
+// Numbers are formatted with the grouping and decimal separators of the active locale.
+// Before a locale is loaded they are formatted by package fmt as is.
+// Amounts of money and ratios could be passed as Currency and Percent parameters.
+//
+//	type M struct {
+//		Total func(g11n.Currency, g11n.Percent) string `default:"Total: %v (%v off)"`
+//	}
+//
+//	M.Total(g11n.Currency{Amount: 1234.5, Code: currency.EUR}, 0.25) // Total: € 1.234,50 (25 % off)
+//
+// Identifiers such as years should be passed as strings to avoid grouping their digits.
+//
 //
 // IV. Format result
 //
@@ -88,4 +113,239 @@
 //	type M struct {
 //		MyLittleSomething func() SafeHTMLFormat `default:"<message>Oops!</message>"`
 //	}
+//
+// A result type that needs the locale, the key or the arguments of the message
+// could implement
+//
+//	G11nResultWithInfo(formattedMessage string, info g11n.MessageInfo) string
+//
+// instead, which is preferred to G11nResult.
+//
+// A result type could also escape each parameter before it is substituted by
+// implementing
+//
+//	G11nEscape(formattedParam string) string
+//
+// The built-in SafeHTML result type escapes the parameters of HTML messages while the
+// markup of the message is kept intact. Its HTML method and the t template func return
+// the message as template.HTML, so html/template does not escape it again.
+//
+//	type M struct {
+//		Hello func(string) g11n.SafeHTML `default:"Hi <b>%v</b>!"`
+//	}
+//
+//	M.Hello("<Bob>") // Hi <b>&lt;Bob&gt;</b>!
+//
+//
+// V. Pseudo-localization
+//
+// Hard-coded strings and layout truncation could be caught before real translations
+// arrive by registering a pseudo-locale that needs no localization file.
+//
+//	G.SetPseudoLocale(g11n.PseudoAccented, g11n.PseudoOptions{Expansion: 30})
+//	G.LoadLocale(g11n.PseudoAccented)
+//
+// Every default message is then accented, padded and wrapped in brackets while its
+// verbs and placeholders are preserved.
+//
+//	M.TheAnswer("everything", 42) // [Ţĥé åñšŵéŕ ţö everything îš 42.~~~~]
+//
+// Pseudo-locales are never negotiated with the locales preferred by users.
+//
+//
+// VI. Dates and times
+//
+// Parameters of type time.Time and time.Duration are formatted according to the active
+// locale. The style of a date or time could be selected with the Date, Time, DateTime and
+// Relative parameter types
+//
+//	type M struct {
+//		Updated func(g11n.Date, g11n.Relative) string `default:"Updated on %v (%v)"`
+//	}
+//
+//	M.Updated(g11n.Date{Time: t, Style: g11n.LongStyle}, g11n.Relative{Time: t}) // Updated on March 7, 2015 (3 days ago)
+//
+// or by a placeholder in the message pattern that refers to a parameter by its position.
+// The supported placeholders are date, time and datetime with an optional short, medium,
+// long or full style, relative and duration. Placeholders do not consume parameters of
+// the verbs in the pattern.
+//
+//	type M struct {
+//		Sent func(string, time.Time) string `default:"%v sent it on {2, date, medium} at {2, time, short}"`
+//	}
+//
+// A style could also be a skeleton prefixed with :: that lists the fields of the date
+// or time, e.g. {1, date, ::yMMMd} or {1, datetime, ::MMMdHm}. The supported skeletons
+// are Md, MMMd, MMMMd, yM, yMd and yMMMd for dates and Hm, Hms, hm and hms for times.
+// Unknown styles fall back to medium. Languages without calendar data use the CLDR root
+// locale and relative times and durations follow the plural rules of the language.
+//
+//
+// VII. Context-aware messages
+//
+// A message func whose first parameter is a context.Context is formatted in the locale
+// attached to the context with WithLocale instead of the active locale of the factory.
+// The context is not passed to the message pattern.
+//
+//	type M struct {
+//		Hello func(context.Context, string) string `default:"Hi %v!"`
+//	}
+//
+//	ctx := g11n.WithLocale(r.Context(), language.Bulgarian)
+//	M.Hello(ctx, "Ivan") // Здравей Ivan!
+//
+// The registered locale that best matches the locale of the context is used, as by
+// Message.In, and the active locale when the context carries no locale. Locales are
+// loaded once and shared by concurrent calls, also while LoadLocale switches the
+// active locale.
+//
+//
+// VIII. Templates
+//
+// The messages of the initialized structs could be formatted in text/template and
+// html/template templates by their keys through the t func of FuncMap.
+//
+//	tmpl := template.New("page").Funcs(g11n.FuncMap(G))
+//
+//	{{t "Messages.TheAnswer" "everything" 42}}
+//	{{t "Messages.TheAnswer" .Context "everything" 42}}
+//
+// A context passed as first argument selects the locale of the message. Messages
+// whose result type is template.HTML are not escaped by html/template, while their
+// parameters are HTML-escaped like those of SafeHTML messages.
+//
+//
+// IX. Select variants
+//
+// A select placeholder chooses a variant of a message, e.g. by grammatical gender, by
+// the value of a parameter. Parameters could name the selected case by implementing
+//
+//	G11nSelect() string
+//
+// while the cases of other parameters are named by their default format. The other case
+// is used when no case matches. The cases refer to parameters by explicit indexes.
+//
+//	type M struct {
+//		Arrived func(Gender, string) string `default:"{1, select, female {%[2]v arrived with her cat} other {%[2]v arrived with their cat}}"`
+//	}
+//
+// Translations could add cases that the default message does not need.
+//
+//	"M.Arrived": "{1, select, female {%[2]v kam mit ihrer Katze} male {%[2]v kam mit seiner Katze} other {%[2]v kam mit der Katze}}"
+//
+//
+// X. Notes for translators
+//
+// The desc and maxlen tags of a message describe its use and limit the number of
+// characters of its translations.
+//
+//	type M struct {
+//		Pay func() string `default:"Pay" desc:"Button on checkout page" maxlen:"20"`
+//	}
+//
+// The tags are returned by Annotation and written as notes in the locale files by
+// g11n extract. Longer translations are reported by g11n check and, when a locale is
+// loaded, logged or rejected according to the policy set by SetMaxLengthPolicy.
+//
+//
+// XI. Introspection
+//
+// The messages of the initialized structs could be listed with their keys, types,
+// default patterns and tags, e.g. by exporters, validators and admin tools.
+//
+//	for _, message := range G.Messages() {
+//		fmt.Println(message.Key, message.Params, message.Default)
+//	}
+//
+//
+// XII. Dynamic messages
+//
+// Messages whose keys are known only at runtime, e.g. error codes from a database,
+// could be formatted by Translate and TranslateContext. A Default passed as first
+// argument is used when the locale has no translation.
+//
+//	G.Translate("Errors.DiskFull", g11n.Default("Disk %v is full."), disk)
+//
+//
+// XIII. Lists
+//
+// The variadic parameter of a message func is expanded into separate arguments.
+//
+//	type M struct {
+//		Friends func(...string) string `default:"%v, %v and %v"`
+//	}
+//
+// A List parameter formats the items of a slice as a localized conjunction or
+// disjunction.
+//
+//	type M struct {
+//		Invited func(g11n.List) string `default:"Invited: %v"`
+//	}
+//
+//	M.Invited(g11n.List{Items: names})                     // Invited: Ana, Bob, and Eve
+//	M.Invited(g11n.List{Items: names, Type: g11n.OrList})  // Invited: Ana, Bob, or Eve
+//
+//
+// XIV. Errors
+//
+// A message func could return an error after its message. The error is a
+// *FormatError when a translation references more parameters than the func has
+// or, unless the func is variadic, leaves some of them out
+// (ErrPlaceholderMismatch), a pattern is malformed (ErrMalformedPattern) or a
+// parameter that implements G11nParam() (string, error) fails to format. The
+// message is still formatted as well as possible.
+//
+//	type M struct {
+//		Hello func(string) (string, error) `default:"Hi %v!"`
+//	}
+//
+//	message, err := M.Hello("Bob")
+//
+// A message func whose result type is error or *g11n.Error returns an *Error that
+// carries the key and the arguments of the message. It could be rendered later in
+// another locale, e.g. in the locale of an API caller.
+//
+//	type M struct {
+//		DiskFull func(string) error `default:"Disk %v is full."`
+//	}
+//
+//	err := M.DiskFull("C")
+//	err.(g11n.LocalizedError).Localize(language.Bulgarian)  // Дискът C е пълен.
+//
+//
+// XV. Lazy messages
+//
+// A message func whose result type is Message returns the key and the arguments
+// of the message without formatting it. The message is localized when it is
+// rendered, by String in the active locale or by In in any registered locale.
+//
+//	type M struct {
+//		Welcome func(string) g11n.Message `default:"Welcome %v!"`
+//	}
+//
+//	message := M.Welcome("Ivan")
+//	message.In(language.Bulgarian)  // Добре дошъл Ivan!
+//
+// A Message could be serialized to JSON and bound to a message factory again
+// after it is deserialized.
+//
+//	json.Unmarshal(data, &message)
+//	G.Bind(message).In(tag)
+//
+//
+// XVI. Caching
+//
+// The dictionary of a locale is read from its file once and cached, so switching
+// locales with LoadLocale is cheap. Its message patterns are parsed once as well,
+// including their placeholders and select cases, like the default patterns of
+// initialized messages. Patterns that substitute only string parameters with %v or
+// %s are rendered without fmt, while other parameters are still formatted by the
+// printer of the locale. Preload reads all registered locales upfront,
+// SetCacheSize bounds the number of cached dictionaries and Invalidate drops the
+// dictionary of a locale whose file has changed.
+//
+//	G.SetCacheSize(20)
+//	G.Preload()
+//
+//	G.Invalidate(language.Bulgarian)
 package g11n
@@ -0,0 +1,97 @@
+// Package env determines the locale of command-line programs from the
+// locale environment variables of POSIX systems.
+package env
+
+import (
+	"os"
+	"strings"
+
+	"github.com/sgatev/g11n"
+
+	"golang.org/x/text/language"
+)
+
+// Locale environment variables in order of precedence for messages.
+var localeVariables = []string{"LC_ALL", "LC_MESSAGES", "LANG"}
+
+// languageVariable holds a colon-separated list of preferred languages.
+const languageVariable = "LANGUAGE"
+
+// scriptModifiers maps locale modifiers to the scripts they select.
+var scriptModifiers = map[string]string{
+	"latin":      "Latn",
+	"cyrillic":   "Cyrl",
+	"devanagari": "Deva",
+}
+
+// Locales returns the locales preferred by the user in order of preference.
+//
+// The locale of messages is taken from the first of LC_ALL, LC_MESSAGES and
+// LANG that is set. As in GNU gettext, the colon-separated list of LANGUAGE
+// takes priority over it unless it is the C or POSIX locale.
+func Locales() []language.Tag {
+	messagesLocale := ""
+	for _, variable := range localeVariables {
+		if value := os.Getenv(variable); value != "" {
+			messagesLocale = value
+			break
+		}
+	}
+
+	messagesTag, ok := Parse(messagesLocale)
+	if !ok {
+		return nil
+	}
+
+	var tags []language.Tag
+	for _, value := range strings.Split(os.Getenv(languageVariable), ":") {
+		if tag, ok := Parse(value); ok {
+			tags = append(tags, tag)
+		}
+	}
+
+	return append(tags, messagesTag)
+}
+
+// Parse converts a POSIX locale name such as de_DE.UTF-8@euro to a language
+// tag. The codeset is ignored and the modifier is only used to select the
+// script, e.g. sr_RS@latin. The C and POSIX locales have no language tag.
+func Parse(locale string) (language.Tag, bool) {
+	name := locale
+	modifier := ""
+
+	if i := strings.IndexByte(name, '@'); i >= 0 {
+		name, modifier = name[:i], name[i+1:]
+	}
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name = name[:i]
+	}
+
+	if name == "" || name == "C" || name == "POSIX" {
+		return language.Und, false
+	}
+
+	parts := strings.SplitN(name, "_", 2)
+	if script, ok := scriptModifiers[strings.ToLower(modifier)]; ok {
+		parts = append(parts[:1], append([]string{script}, parts[1:]...)...)
+	}
+
+	tag, err := language.Parse(strings.Join(parts, "-"))
+	if err != nil {
+		return language.Und, false
+	}
+
+	return tag, true
+}
+
+// SetLocale loads the registered locale of a MessageFactory that best
+// matches the locale environment variables. The default locale of the
+// factory is loaded if none of the registered locales matches.
+func SetLocale(mf *g11n.MessageFactory) {
+	tag, _ := mf.MatchLocale(Locales()...)
+	if tag == language.Und {
+		return
+	}
+
+	mf.LoadLocale(tag)
+}
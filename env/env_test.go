@@ -0,0 +1,137 @@
+package env_test
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/env"
+	. "github.com/sgatev/g11n/test"
+)
+
+func testMessage(t *testing.T, actual, expected string) {
+	if actual != expected {
+		t.Errorf("Message is not the same as expected.\n"+
+			"\tActual: %v\n"+
+			"\tExpected: %v\n", actual, expected)
+	}
+}
+
+// setenv sets the locale environment variables for the duration of a test.
+func setenv(t *testing.T, lcAll, lcMessages, lang, languages string) {
+	setenvVar(t, "LC_ALL", lcAll)
+	setenvVar(t, "LC_MESSAGES", lcMessages)
+	setenvVar(t, "LANG", lang)
+	setenvVar(t, "LANGUAGE", languages)
+}
+
+// setenvVar sets an environment variable and restores it when a test ends.
+func setenvVar(t *testing.T, name, value string) {
+	previous, ok := os.LookupEnv(name)
+	os.Setenv(name, value)
+
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(name, previous)
+		} else {
+			os.Unsetenv(name)
+		}
+	})
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		locale   string
+		expected string
+	}{
+		{"de_DE.UTF-8@euro", "de-DE"},
+		{"bg_BG.UTF-8", "bg-BG"},
+		{"sr_RS@latin", "sr-Latn-RS"},
+		{"pt_BR", "pt-BR"},
+		{"es", "es"},
+	}
+
+	for _, test := range tests {
+		tag, ok := Parse(test.locale)
+		if !ok {
+			t.Errorf("Locale %q is not parsed.", test.locale)
+			continue
+		}
+
+		testMessage(t, tag.String(), test.expected)
+	}
+
+	for _, locale := range []string{"", "C", "POSIX", "C.UTF-8", "$$"} {
+		if tag, ok := Parse(locale); ok {
+			t.Errorf("Locale %q is parsed as %v.", locale, tag)
+		}
+	}
+}
+
+func TestLocales(t *testing.T) {
+	tests := []struct {
+		lcAll, lcMessages, lang, languages string
+		expected                           []language.Tag
+	}{
+		{"", "", "de_DE.UTF-8@euro", "", []language.Tag{
+			language.MustParse("de-DE"),
+		}},
+		{"", "es_ES.UTF-8", "de_DE.UTF-8", "", []language.Tag{
+			language.MustParse("es-ES"),
+		}},
+		{"bg_BG.UTF-8", "es_ES.UTF-8", "de_DE.UTF-8", "", []language.Tag{
+			language.MustParse("bg-BG"),
+		}},
+		{"", "", "de_DE.UTF-8", "fr_FR:it::bg", []language.Tag{
+			language.MustParse("fr-FR"),
+			language.MustParse("it"),
+			language.MustParse("bg"),
+			language.MustParse("de-DE"),
+		}},
+		{"C", "", "de_DE.UTF-8", "fr_FR", nil},
+		{"", "", "", "fr_FR", nil},
+	}
+
+	for _, test := range tests {
+		setenv(t, test.lcAll, test.lcMessages, test.lang, test.languages)
+
+		if actual := Locales(); !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Locales are not correct.\n"+
+				"Expected: %v\n"+
+				"Actual: %v\n", test.expected, actual)
+		}
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	type M struct {
+		MyLittleSomething func() string `default:"cat"`
+	}
+
+	factory := New()
+
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"M.MyLittleSomething": "котка"}`),
+		language.Spanish:   TempFile(`{"M.MyLittleSomething": "gato"}`),
+	}, "json")
+	factory.SetDefaultLocale(language.Spanish)
+
+	m := factory.Init(&M{}).(*M)
+
+	setenv(t, "", "", "bg_BG.UTF-8", "")
+	SetLocale(factory)
+	testMessage(t, m.MyLittleSomething(), "котка")
+
+	setenv(t, "", "", "ja_JP.UTF-8", "")
+	SetLocale(factory)
+	testMessage(t, m.MyLittleSomething(), "gato")
+}
+
+func TestSetLocaleWithoutLocales(t *testing.T) {
+	setenv(t, "", "", "bg_BG.UTF-8", "")
+
+	SetLocale(New())
+}
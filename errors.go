@@ -0,0 +1,157 @@
+package g11n
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// errorType is the reflected type of error.
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// Errors of message funcs that return an error.
+var (
+	// ErrPlaceholderMismatch is reported when a message pattern references
+	// more parameters than are passed to its message func or, unless the
+	// func is variadic, leaves some of them unreferenced.
+	ErrPlaceholderMismatch = errors.New("g11n: placeholders do not match the parameters")
+
+	// ErrMalformedPattern is reported when a message pattern contains verbs
+	// that fmt can not parse or that do not match the types of their
+	// parameters.
+	ErrMalformedPattern = errors.New("g11n: malformed message pattern")
+)
+
+// FormatError is returned by a message func of shape func(...) (T, error)
+// when its message could not be formatted.
+type FormatError struct {
+
+	// Key is the key of the message.
+	Key string
+
+	// Locale is the locale the message was formatted in.
+	Locale language.Tag
+
+	// Err is the cause of the error, e.g. ErrPlaceholderMismatch or the error
+	// of a parameter formatter.
+	Err error
+}
+
+// Error implements error.
+func (e *FormatError) Error() string {
+	return fmt.Sprintf("g11n: message '%v' in locale '%v': %v", e.Key, e.Locale, e.Err)
+}
+
+// Unwrap returns the cause of the error.
+func (e *FormatError) Unwrap() error {
+	return e.Err
+}
+
+// resultsString describes the results of a func type, e.g. (string, int).
+func resultsString(funcType reflect.Type) string {
+	results := make([]string, funcType.NumOut())
+	for i := range results {
+		results[i] = funcType.Out(i).String()
+	}
+
+	return "(" + strings.Join(results, ", ") + ")"
+}
+
+// hasMessageResults checks if the results of a message func are either a
+// single message or a message and an error.
+func hasMessageResults(funcType reflect.Type) bool {
+	switch funcType.NumOut() {
+	case 1:
+		return true
+	case 2:
+		return funcType.Out(1) == errorType
+	default:
+		return false
+	}
+}
+
+// LocalizedError is an error whose message could be rendered in any of the
+// registered locales.
+type LocalizedError interface {
+	error
+
+	// Localize returns the message of the error in the registered locale
+	// that best matches a locale.
+	Localize(tag language.Tag) string
+}
+
+// Error is the error returned by message funcs whose result type is error or
+// *Error. It carries the key and the arguments of its message, so that it
+// could be translated late, e.g. in the locale of an API caller.
+type Error struct {
+
+	// Key is the key of the message.
+	Key string
+
+	// Args are the arguments the message func was called with.
+	Args []interface{}
+
+	message        string
+	defaultPattern *compiledPattern
+	factory        *MessageFactory
+}
+
+// localizedErrorType is the reflected type of *Error.
+var localizedErrorType = reflect.TypeOf((*Error)(nil))
+
+// Error returns the message of the error in the locale it was created in.
+// The key is returned if the error is not created by a message func.
+func (e *Error) Error() string {
+	if e.factory == nil {
+		return e.Key
+	}
+
+	return e.message
+}
+
+// Localize implements LocalizedError. The key is returned if the error is
+// not created by a message func.
+func (e *Error) Localize(tag language.Tag) string {
+	if e.factory == nil {
+		return e.Key
+	}
+
+	dictionary := e.factory.localeDictionary(e.factory.resolveLocale(tag))
+
+	message, _ := dictionary.format(
+		dictionary.lookupPattern(e.Key, e.defaultPattern), interfaceValues(e.Args), nil)
+
+	return message
+}
+
+// isErrorResult checks if message funcs with a result type return an *Error.
+func isErrorResult(resultType reflect.Type) bool {
+	return resultType == errorType || resultType == localizedErrorType
+}
+
+// localizedError creates the *Error result of a message func.
+func (mf *MessageFactory) localizedError(
+	message, messageKey string,
+	defaultPattern *compiledPattern,
+	args []reflect.Value,
+	resultType reflect.Type) reflect.Value {
+
+	localizedError := &Error{
+		Key:            messageKey,
+		Args:           make([]interface{}, len(args)),
+		message:        message,
+		defaultPattern: defaultPattern,
+		factory:        mf,
+	}
+	for i, arg := range args {
+		localizedError.Args[i] = arg.Interface()
+	}
+
+	result := reflect.New(resultType).Elem()
+	result.Set(reflect.ValueOf(localizedError))
+
+	return result
+}
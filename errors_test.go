@@ -0,0 +1,288 @@
+package g11n_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+type ErrorMessages struct {
+	Hello   func(string) (string, error)   `default:"Hi %v!"`
+	Safe    func(string) (SafeHTML, error) `default:"Hi <b>%v</b>!"`
+	Account func(AccountParam) (string, error)
+}
+
+// AccountParam is a parameter whose formatting may fail.
+type AccountParam string
+
+func (ap AccountParam) G11nParam() (string, error) {
+	if ap == "" {
+		return "", errors.New("empty account")
+	}
+
+	return "#" + string(ap), nil
+}
+
+// testError checks that an error is a FormatError of a message, caused by
+// the expected error if any.
+func testError(t *testing.T, err error, key string, expected error) {
+	var formatError *FormatError
+	if !errors.As(err, &formatError) || formatError.Key != key ||
+		expected != nil && !errors.Is(err, expected) {
+		t.Errorf("Error is not the same as expected.\n"+
+			"\tActual: %v\n"+
+			"\tExpected: %v\n", err, expected)
+	}
+}
+
+func TestMessageWithError(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "ErrorMessages.Hello": "Здравей %v и %v!",
+	  "ErrorMessages.Safe": "Здравей <b>%d</b>!",
+	  "ErrorMessages.Account": "Сметка %v"
+	}
+`))
+
+	m := factory.Init(&ErrorMessages{}).(*ErrorMessages)
+
+	message, err := m.Hello("Bob")
+	testMessage(t, message, "Hi Bob!")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	safe, err := m.Safe("<Bob>")
+	testMessage(t, string(safe), "Hi <b>&lt;Bob&gt;</b>!")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestMessageWithErrorPlaceholderMismatch(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "ErrorMessages.Hello": "Здравей %v и %v!",
+	  "ErrorMessages.Safe": "Здравей <b>%d</b>!",
+	  "ErrorMessages.Account": "Сметка %v"
+	}
+`))
+
+	m := factory.Init(&ErrorMessages{}).(*ErrorMessages)
+	factory.LoadLocale(language.Bulgarian)
+
+	message, err := m.Hello("Иван")
+	testMessage(t, message, "Здравей Иван и %!v(MISSING)!")
+	testError(t, err, "ErrorMessages.Hello", ErrPlaceholderMismatch)
+}
+
+func TestMessageWithErrorExtraParams(t *testing.T) {
+	type M struct {
+		Hello func(string, string) (string, error)    `default:"Hi %v!"`
+		Visit func(string, ...string) (string, error) `default:"Visit %v"`
+	}
+
+	m := New().Init(&M{}).(*M)
+
+	_, err := m.Hello("Ana", "Bob")
+	testError(t, err, "M.Hello", ErrPlaceholderMismatch)
+
+	message, err := m.Visit("Ana")
+	testMessage(t, message, "Visit Ana")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestMessageWithErrorMalformedPattern(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "ErrorMessages.Hello": "Здравей %v и %v!",
+	  "ErrorMessages.Safe": "Здравей <b>%d</b>!",
+	  "ErrorMessages.Account": "Сметка %v"
+	}
+`))
+
+	m := factory.Init(&ErrorMessages{}).(*ErrorMessages)
+	factory.LoadLocale(language.Bulgarian)
+
+	_, err := m.Safe("Иван")
+	testError(t, err, "ErrorMessages.Safe", ErrMalformedPattern)
+}
+
+func TestMessageWithErrorParamFormatter(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "ErrorMessages.Hello": "Здравей %v и %v!",
+	  "ErrorMessages.Safe": "Здравей <b>%d</b>!",
+	  "ErrorMessages.Account": "Сметка %v"
+	}
+`))
+
+	m := factory.Init(&ErrorMessages{}).(*ErrorMessages)
+	factory.LoadLocale(language.Bulgarian)
+
+	message, err := m.Account("42")
+	testMessage(t, message, "Сметка #42")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	_, err = m.Account("")
+	testError(t, err, "ErrorMessages.Account", nil)
+
+	testMessage(t, err.Error(), "g11n: message 'ErrorMessages.Account' in locale 'bg': empty account")
+}
+
+func TestMessageWithErrorWrongResult(t *testing.T) {
+	type M struct {
+		Answer func() (string, bool) `default:"Oops!"`
+	}
+
+	defer MustPanic(t, "Wrong results of a g11n message. Expected a message and an optional error, got (string, bool).")
+
+	New().Init(&M{})
+}
+
+type LocalizedErrorMessages struct {
+	DiskFull func(string) error                   `default:"Disk %v is full."`
+	NotFound func(context.Context, string) *Error `default:"%v not found."`
+}
+
+func TestLocalizedError(t *testing.T) {
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`
+		{
+		  "LocalizedErrorMessages.DiskFull": "Дискът %v е пълен.",
+		  "LocalizedErrorMessages.NotFound": "%v не е намерен."
+		}
+		`),
+		language.Spanish: TempFile(`
+		{
+		  "LocalizedErrorMessages.DiskFull": "El disco %v está lleno."
+		}
+		`),
+	}, "json")
+
+	m := factory.Init(&LocalizedErrorMessages{}).(*LocalizedErrorMessages)
+
+	err := m.DiskFull("C")
+	testMessage(t, err.Error(), "Disk C is full.")
+
+	localizedError, ok := err.(LocalizedError)
+	if !ok {
+		t.Fatalf("Error %T is not a LocalizedError.", err)
+	}
+
+	testMessage(t, localizedError.Localize(language.Bulgarian), "Дискът C е пълен.")
+	testMessage(t, localizedError.Localize(language.MustParse("es-MX")), "El disco C está lleno.")
+
+	var g11nError *Error
+	if !errors.As(err, &g11nError) || g11nError.Key != "LocalizedErrorMessages.DiskFull" {
+		t.Errorf("Error %v is not a g11n error.", err)
+	}
+}
+
+func TestLocalizedErrorUnbound(t *testing.T) {
+	err := &Error{Key: "LocalizedErrorMessages.DiskFull", Args: []interface{}{"C"}}
+
+	testMessage(t, err.Error(), "LocalizedErrorMessages.DiskFull")
+	testMessage(t, err.Localize(language.Bulgarian), "LocalizedErrorMessages.DiskFull")
+}
+
+func TestLocalizedErrorContext(t *testing.T) {
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`
+		{
+		  "LocalizedErrorMessages.DiskFull": "Дискът %v е пълен.",
+		  "LocalizedErrorMessages.NotFound": "%v не е намерен."
+		}
+		`),
+		language.Spanish: TempFile(`
+		{
+		  "LocalizedErrorMessages.DiskFull": "El disco %v está lleno."
+		}
+		`),
+	}, "json")
+
+	m := factory.Init(&LocalizedErrorMessages{}).(*LocalizedErrorMessages)
+
+	err := m.NotFound(WithLocale(context.Background(), language.Bulgarian), "Файл")
+	testMessage(t, err.Error(), "Файл не е намерен.")
+	testMessage(t, err.Localize(language.Spanish), "Файл not found.")
+
+	if len(err.Args) != 1 || err.Args[0] != "Файл" {
+		t.Errorf("Error arguments are not the same as expected: %v", err.Args)
+	}
+}
+
+func TestLocalizedErrorTranslate(t *testing.T) {
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`
+		{
+		  "LocalizedErrorMessages.DiskFull": "Дискът %v е пълен.",
+		  "LocalizedErrorMessages.NotFound": "%v не е намерен."
+		}
+		`),
+		language.Spanish: TempFile(`
+		{
+		  "LocalizedErrorMessages.DiskFull": "El disco %v está lleno."
+		}
+		`),
+	}, "json")
+
+	factory.Init(&LocalizedErrorMessages{})
+	factory.LoadLocale(language.Bulgarian)
+
+	testMessage(t,
+		factory.Translate("LocalizedErrorMessages.DiskFull", "D"),
+		"Дискът D е пълен.")
+}
+
+func TestMessageWithErrorPercentInParam(t *testing.T) {
+	type M struct {
+		Count func(int, string) (string, error) `default:"%d %v"`
+	}
+
+	m := New().Init(&M{}).(*M)
+
+	message, err := m.Count(1, "wow%!")
+	testMessage(t, message, "1 wow%!")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestMessageWithErrorMalformedSyntax(t *testing.T) {
+	type M struct {
+		Trailing func(string) (string, error) `default:"%v 100%"`
+		Unknown  func(string) (string, error) `default:"%y"`
+		Percent  func(string) (string, error) `default:"%v 100%%"`
+	}
+
+	m := New().Init(&M{}).(*M)
+
+	_, err := m.Trailing("Bob")
+	testError(t, err, "M.Trailing", ErrMalformedPattern)
+
+	_, err = m.Unknown("Bob")
+	testError(t, err, "M.Unknown", ErrMalformedPattern)
+
+	message, err := m.Percent("Bob")
+	testMessage(t, message, "Bob 100%")
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
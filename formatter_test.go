@@ -0,0 +1,92 @@
+package g11n_test
+
+import (
+	"fmt"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+// Quote is a parameter that is quoted in the style of its locale.
+type Quote string
+
+func (q Quote) G11nParamLocale(tag language.Tag) string {
+	if base, _ := tag.Base(); base.String() == "bg" {
+		return "„" + string(q) + "“"
+	}
+
+	return "“" + string(q) + "”"
+}
+
+// Annotated is a result that is annotated with the call of its message.
+type Annotated string
+
+func (Annotated) G11nResultWithInfo(formattedMessage string, info MessageInfo) string {
+	return fmt.Sprintf("%v [%v %v %v]", formattedMessage, info.Tag, info.Key, info.Args)
+}
+
+type FormatterMessages struct {
+	Said   func(string, Quote) string `default:"%v said %v."`
+	Count  func(int) Annotated        `default:"%d cats"`
+	Title  Annotated                  `default:"Cats"`
+	Quotes func(List) string          `default:"Quotes: %v"`
+}
+
+func TestParamLocaleFormatter(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "FormatterMessages.Said": "%v каза %v."
+	}
+`))
+	m := factory.Init(&FormatterMessages{}).(*FormatterMessages)
+
+	testMessage(t, m.Said("Bob", "Hi"), "Bob said “Hi”.")
+	testMessage(t,
+		m.Quotes(List{Items: []Quote{"a", "b"}}),
+		"Quotes: “a” and “b”")
+
+	factory.LoadLocale(language.Bulgarian)
+
+	testMessage(t, m.Said("Иван", "Здравей"), "Иван каза „Здравей“.")
+}
+
+func TestResultInfoFormatter(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "FormatterMessages.Count": "%d котки"
+	}
+`))
+	m := factory.Init(&FormatterMessages{}).(*FormatterMessages)
+
+	testMessage(t, string(m.Count(3)), "3 cats [und FormatterMessages.Count [3]]")
+	testMessage(t, string(m.Title), "Cats [und FormatterMessages.Title []]")
+
+	factory.LoadLocale(language.Bulgarian)
+
+	testMessage(t, string(m.Count(3)), "3 котки [bg FormatterMessages.Count [3]]")
+	testMessage(t, string(m.Title), "Cats [bg FormatterMessages.Title []]")
+	testMessage(t,
+		factory.Translate("FormatterMessages.Count", 5),
+		"5 котки [bg FormatterMessages.Count [5]]")
+}
+
+func TestFormatParam(t *testing.T) {
+	param, err := FormatParam(Quote("Hi"), language.Bulgarian)
+	testMessage(t, fmt.Sprint(param), "„Hi“")
+	if err != nil {
+		t.Errorf("Unexpected error %v.", err)
+	}
+
+	param, _ = FormatParam(List{Items: []string{"a", "b"}}, language.English)
+	testMessage(t, fmt.Sprint(param), "a and b")
+
+	param, _ = FormatParam(42, language.Bulgarian)
+	if param != 42 {
+		t.Errorf("Plain parameter is modified: %v.", param)
+	}
+}
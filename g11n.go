@@ -1,12 +1,25 @@
 package g11n
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	g11nLocale "github.com/sgatev/g11n/locale"
 
+	"golang.org/x/text/currency"
 	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/message/catalog"
+	"golang.org/x/text/number"
 )
 
 // Application constants.
@@ -16,9 +29,10 @@ const (
 
 // Error message patterns.
 const (
-	wrongResultsCountMessage = "Wrong number of results in a g11n message. Expected 1, got %v."
-	unknownFormatMessage     = "Unknown locale format '%v'."
-	unknownLocaleTag         = "Unknown locale '%v'."
+	wrongResultsMessage  = "Wrong results of a g11n message. Expected a message and an optional error, got %v."
+	unknownFormatMessage = "Unknown locale format '%v'."
+	unknownLocaleTag     = "Unknown locale '%v'."
+	unknownDefaultLocale = "Unknown default locale '%v'."
 )
 
 // paramFormatter represents a type that supports custom formatting
@@ -29,6 +43,30 @@ type paramFormatter interface {
 	G11nParam() string
 }
 
+// paramErrorFormatter represents a type that supports custom formatting
+// that may fail when it is used as parameter in a call to a g11n message.
+type paramErrorFormatter interface {
+
+	// G11nParam formats a type in a specific way when passed to a g11n message.
+	G11nParam() (string, error)
+}
+
+// paramLocaleFormatter represents a type that supports custom formatting
+// for each locale when it is used as parameter in a call to a g11n message.
+type paramLocaleFormatter interface {
+
+	// G11nParamLocale formats a type for the locale of a g11n message.
+	G11nParamLocale(tag language.Tag) string
+}
+
+// paramSelector represents a type that selects a case of a select
+// placeholder when it is used as parameter in a call to a g11n message.
+type paramSelector interface {
+
+	// G11nSelect returns the name of the case selected by a parameter.
+	G11nSelect() string
+}
+
 // resultFormatter represents a type that supports custom formatting
 // when it is returned from a call to a g11n message.
 type resultFormatter interface {
@@ -37,11 +75,93 @@ type resultFormatter interface {
 	G11nResult(formattedMessage string) string
 }
 
+// resultInfoFormatter represents a type that supports custom formatting
+// based on the locale and the call of a g11n message it is returned from.
+type resultInfoFormatter interface {
+
+	// G11nResultWithInfo accepts a formatted g11n message and the description
+	// of its call and modifies the message before returning.
+	G11nResultWithInfo(formattedMessage string, info MessageInfo) string
+}
+
+// MessageInfo describes a call of a g11n message for result formatters.
+type MessageInfo struct {
+
+	// Tag is the locale the message is formatted in.
+	Tag language.Tag
+
+	// Key is the key of the message.
+	Key string
+
+	// Args are the arguments of the call, without a leading context.
+	Args []interface{}
+}
+
+// newMessageInfo describes a call of a g11n message.
+func newMessageInfo(tag language.Tag, messageKey string, args []reflect.Value) MessageInfo {
+	info := MessageInfo{
+		Tag:  tag,
+		Key:  messageKey,
+		Args: make([]interface{}, len(args)),
+	}
+	for i, arg := range args {
+		info.Args[i] = arg.Interface()
+	}
+
+	return info
+}
+
 type stringInitializer func()
 
-// formatParam extracts the data from a reflected argument value and returns it.
-func formatParam(value reflect.Value) interface{} {
-	valueInterface := value.Interface()
+// formatParam extracts the data from a reflected argument value and returns it
+// formatted for a locale.
+func formatParam(value reflect.Value, tag language.Tag) (interface{}, error) {
+	return FormatParam(value.Interface(), tag)
+}
+
+// FormatParam formats a parameter of a g11n message for a locale the way
+// the message funcs of a MessageFactory do. It does not use reflection and
+// is meant for code generated by g11n-gen.
+func FormatParam(valueInterface interface{}, tag language.Tag) (interface{}, error) {
+	switch paramFormatter := valueInterface.(type) {
+	case paramLocaleFormatter:
+		return paramFormatter.G11nParamLocale(tag), nil
+	case paramErrorFormatter:
+		return paramFormatter.G11nParam()
+	}
+
+	return formatValue(valueInterface, tag), nil
+}
+
+// formatValue formats a message parameter for a locale.
+func formatValue(valueInterface interface{}, tag language.Tag) interface{} {
+	switch param := valueInterface.(type) {
+	case Currency:
+		return currency.Symbol(param.Code.Amount(param.Amount))
+	case Percent:
+		if tag == language.Und {
+			return strconv.FormatFloat(math.Round(float64(param)*100), 'f', -1, 64) + "%"
+		}
+		return number.Percent(float64(param))
+	case time.Time:
+		return formatDateTime(tag, param, MediumStyle)
+	case time.Duration:
+		return formatDuration(tag, param)
+	case Date:
+		return formatDate(tag, param.Time, param.Style)
+	case Time:
+		return formatTime(tag, param.Time, param.Style)
+	case DateTime:
+		return formatDateTime(tag, param.Time, param.Style)
+	case List:
+		return formatList(tag, param)
+	case Relative:
+		now := param.Now
+		if now.IsZero() {
+			now = time.Now()
+		}
+		return formatRelative(tag, param.Time.Sub(now))
+	}
 
 	if paramFormatter, ok := valueInterface.(paramFormatter); ok {
 		return paramFormatter.G11nParam()
@@ -54,70 +174,407 @@ func formatParam(value reflect.Value) interface{} {
 type localeInfo struct {
 	format string
 	path   string
+	pseudo *PseudoOptions
+}
+
+// emptyCatalog prevents message printers from looking up patterns in the
+// default catalog of golang.org/x/text/message.
+var emptyCatalog = catalog.NewBuilder()
+
+// newPrinter creates a printer that formats numbers for a locale. There is
+// no printer for language.Und, i.e. before a locale is loaded, so that the
+// numbers of default messages are formatted by package fmt.
+func newPrinter(tag language.Tag) *message.Printer {
+	if tag == language.Und {
+		return nil
+	}
+
+	return message.NewPrinter(tag, message.Catalog(emptyCatalog))
+}
+
+// sprintf formats according to a format specifier with a printer, or with
+// package fmt if there is no printer.
+func sprintf(printer *message.Printer, format string, args ...interface{}) string {
+	if printer == nil {
+		return fmt.Sprintf(format, args...)
+	}
+
+	return printer.Sprintf(format, args...)
+}
+
+// dictionary holds the translated messages of a locale.
+type dictionary struct {
+	tag      language.Tag
+	messages map[string]string
+	patterns map[string]*compiledPattern
+	pseudo   *PseudoOptions
+	printer  *message.Printer
+
+	// pseudoPatterns caches the compiled pseudo-localized default patterns.
+	pseudoPatterns sync.Map
+}
+
+// newDictionary creates a dictionary that formats messages for a locale.
+// The translated messages are compiled upfront.
+func newDictionary(tag language.Tag, messages map[string]string, pseudo *PseudoOptions) *dictionary {
+	patterns := make(map[string]*compiledPattern, len(messages))
+	for messageKey, messagePattern := range messages {
+		patterns[messageKey] = compilePattern(messagePattern)
+	}
+
+	return &dictionary{
+		tag:      tag,
+		messages: messages,
+		patterns: patterns,
+		pseudo:   pseudo,
+		printer:  newPrinter(tag),
+	}
+}
+
+// lookup returns the pattern of a message in the dictionary, falling back
+// to the default pattern of the message.
+func (d *dictionary) lookup(messageKey, defaultPattern string) string {
+	if message, ok := d.messages[messageKey]; ok {
+		return message
+	}
+
+	if d.pseudo != nil {
+		return d.pseudo.transform(defaultPattern)
+	}
+
+	return defaultPattern
+}
+
+// lookupPattern returns the compiled pattern of a message in the dictionary,
+// falling back to the default pattern of the message.
+func (d *dictionary) lookupPattern(messageKey string, defaultPattern *compiledPattern) *compiledPattern {
+	if compiled, ok := d.patterns[messageKey]; ok {
+		return compiled
+	}
+
+	if d.pseudo != nil {
+		if compiled, ok := d.pseudoPatterns.Load(defaultPattern.source); ok {
+			return compiled.(*compiledPattern)
+		}
+
+		compiled := compilePattern(d.pseudo.transform(defaultPattern.source))
+		d.pseudoPatterns.Store(defaultPattern.source, compiled)
+
+		return compiled
+	}
+
+	return defaultPattern
+}
+
+// format substitutes the arguments in a message pattern, formatting them
+// according to the locale of the dictionary. The formatted arguments are
+// escaped by the escaper, if any.
+//
+// An error is returned if a parameter could not be formatted or the pattern
+// could not be formatted with the parameters. The message is formatted as
+// well as possible in that case.
+func (d *dictionary) format(compiled *compiledPattern, args []reflect.Value, escaper paramEscaper) (string, error) {
+	if message, ok := compiled.formatDirect(args, escaper); ok {
+		return message, nil
+	}
+
+	var err error
+
+	params := make([]interface{}, len(args))
+	for i, arg := range args {
+		var paramErr error
+		if params[i], paramErr = formatParam(arg, d.tag); paramErr != nil && err == nil {
+			err = paramErr
+		}
+	}
+
+	switch {
+	case err != nil:
+	case compiled.count > len(args):
+		err = ErrPlaceholderMismatch
+	case compiled.malformed || compiled.mismatchedVerb(params):
+		err = ErrMalformedPattern
+	}
+
+	messagePattern := compiled.source
+	expandedPattern := messagePattern
+	if len(compiled.placeholders) > 0 {
+		expandedPattern = d.expandPlaceholders(compiled, args, params, escaper)
+	}
+
+	// Reference an empty trailing argument explicitly, so that arguments
+	// used only by placeholders are not reported as extra.
+	if expandedPattern != messagePattern {
+		params = append(params, "")
+		expandedPattern += fmt.Sprintf("%%[%d]s", len(params))
+	}
+
+	if escaper != nil {
+		for i, param := range params {
+			params[i] = escapedParam{
+				value:   param,
+				printer: d.printer,
+				escaper: escaper,
+			}
+		}
+	}
+
+	message := sprintf(d.printer, expandedPattern, params...)
+
+	return message, err
+}
+
+// selectCase returns the name of the case of a select placeholder that is
+// selected by an argument.
+func selectCase(value reflect.Value) string {
+	valueInterface := value.Interface()
+
+	if paramSelector, ok := valueInterface.(paramSelector); ok {
+		return paramSelector.G11nSelect()
+	}
+
+	return fmt.Sprint(valueInterface)
+}
+
+// expandPlaceholders replaces the select placeholders of a compiled pattern
+// with the patterns of the cases selected by their arguments, and the other
+// placeholders with their arguments formatted as requested. Placeholders do
+// not consume arguments of the verbs in the pattern.
+func (d *dictionary) expandPlaceholders(compiled *compiledPattern, args []reflect.Value, params []interface{}, escaper paramEscaper) string {
+	buffer := bufferPool.Get().(*bytes.Buffer)
+	defer bufferPool.Put(buffer)
+	buffer.Reset()
+
+	d.writePlaceholders(buffer, compiled.source, compiled.placeholders, args, params, escaper)
+
+	return buffer.String()
+}
+
+// writePlaceholders writes a pattern with its placeholders expanded.
+func (d *dictionary) writePlaceholders(
+	buffer *bytes.Buffer,
+	messagePattern string,
+	placeholders []compiledPlaceholder,
+	args []reflect.Value,
+	params []interface{},
+	escaper paramEscaper) {
+
+	last := 0
+	for _, placeholder := range placeholders {
+		if placeholder.Arg >= len(args) {
+			continue
+		}
+
+		if placeholder.cases != nil {
+			selected := placeholder.selected(selectCase(args[placeholder.Arg]))
+
+			buffer.WriteString(messagePattern[last:placeholder.Start])
+			d.writePlaceholders(buffer, selected.source, selected.placeholders, args, params, escaper)
+			last = placeholder.End
+			continue
+		}
+
+		formatter, ok := placeholderFormatters[placeholder.Type]
+		if !ok {
+			continue
+		}
+
+		formatted, ok := formatter(d.tag, args[placeholder.Arg].Interface(), placeholder.Style)
+		if !ok {
+			formatted = fmt.Sprint(params[placeholder.Arg])
+		}
+		if escaper != nil {
+			formatted = escaper.G11nEscape(formatted)
+		}
+
+		buffer.WriteString(messagePattern[last:placeholder.Start])
+		buffer.WriteString(strings.Replace(formatted, "%", "%%", -1))
+		last = placeholder.End
+	}
+	buffer.WriteString(messagePattern[last:])
+}
+
+// load parses the localization file of a locale into a dictionary.
+func (locale localeInfo) load(tag language.Tag) *dictionary {
+	if locale.pseudo != nil {
+		return newDictionary(tag, map[string]string{}, locale.pseudo)
+	}
+
+	loader, ok := g11nLocale.GetLoader(locale.format)
+	if !ok {
+		panic(fmt.Sprintf(unknownFormatMessage, locale.format))
+	}
+
+	return newDictionary(tag, loader.Load(locale.path), nil)
 }
 
 // MessageFactory initializes message structs and provides language
 // translations to messages.
 type MessageFactory struct {
 	locales            map[language.Tag]localeInfo
-	dictionary         map[string]string
+	localesOrder       []language.Tag
+	defaultLocale      language.Tag
 	stringInitializers []stringInitializer
+
+	// dictionary holds the *dictionary of the active locale. It is replaced
+	// atomically, so that messages could be formatted while a locale loads.
+	dictionary atomic.Value
+
+	// messages holds the messages of the initialized structs by key and
+	// compiledDefaults the compiled default patterns of their funcs.
+	messages         map[string]Descriptor
+	compiledDefaults map[string]*compiledPattern
+	messageKeys      []string
+	messagesMutex    sync.RWMutex
+	maxLengthPolicy  MaxLengthPolicy
+
+	// dictionaries caches the loaded dictionaries of the locales.
+	dictionaries *dictionaryCache
 }
 
 // New returns a fresh G11n message factory.
 func New() *MessageFactory {
-	return &MessageFactory{
-		dictionary: map[string]string{},
-		locales:    map[language.Tag]localeInfo{},
+	factory := &MessageFactory{
+		dictionaries:     newDictionaryCache(),
+		locales:          map[language.Tag]localeInfo{},
+		messages:         map[string]Descriptor{},
+		compiledDefaults: map[string]*compiledPattern{},
 	}
+	factory.dictionary.Store(newDictionary(language.Und, map[string]string{}, nil))
+
+	return factory
 }
 
-// Locales returns the registered locales in a message factory.
+// activeDictionary returns the dictionary of the active locale.
+func (mf *MessageFactory) activeDictionary() *dictionary {
+	return mf.dictionary.Load().(*dictionary)
+}
+
+// Locales returns the registered locales in a message factory in order of
+// registration. The default locale, if any, is always first. Pseudo-locales
+// are left out, so that they are never negotiated for real users.
 func (mf *MessageFactory) Locales() []language.Tag {
-	locales := make([]language.Tag, 0, len(mf.locales))
+	locales := make([]language.Tag, 0, len(mf.localesOrder))
+
+	if _, ok := mf.DefaultLocale(); ok && mf.locales[mf.defaultLocale].pseudo == nil {
+		locales = append(locales, mf.defaultLocale)
+	}
 
-	for locale := range mf.locales {
-		locales = append(locales, locale)
+	for _, locale := range mf.localesOrder {
+		if locale != mf.defaultLocale && mf.locales[locale].pseudo == nil {
+			locales = append(locales, locale)
+		}
 	}
 
 	return locales
 }
 
+// SetDefaultLocale selects the registered locale that is used when none of
+// the registered locales matches the preferred ones.
+func (mf *MessageFactory) SetDefaultLocale(tag language.Tag) {
+	if _, ok := mf.locales[tag]; !ok {
+		panic(fmt.Sprintf(unknownDefaultLocale, tag))
+	}
+
+	mf.defaultLocale = tag
+}
+
+// DefaultLocale returns the default locale of a message factory.
+func (mf *MessageFactory) DefaultLocale() (language.Tag, bool) {
+	return mf.defaultLocale, mf.defaultLocale != language.Und
+}
+
+// MatchLocale returns the registered locale that best matches a list of
+// preferred locales and the confidence of the match. The result is always
+// one of Locales, falling back to the default locale with No confidence,
+// or language.Und if no locales are registered.
+func (mf *MessageFactory) MatchLocale(preferred ...language.Tag) (language.Tag, language.Confidence) {
+	supported := mf.Locales()
+	if len(supported) == 0 {
+		return language.Und, language.No
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(preferred...)
+
+	// The matcher returns the first supported locale when nothing matches.
+	if confidence == language.No {
+		index = 0
+	}
+
+	return supported[index], confidence
+}
+
+// resolveLocale returns a registered locale as is, including pseudo-locales,
+// and the best match of any other locale.
+func (mf *MessageFactory) resolveLocale(tag language.Tag) language.Tag {
+	if _, ok := mf.locales[tag]; ok {
+		return tag
+	}
+
+	tag, _ = mf.MatchLocale(tag)
+
+	return tag
+}
+
 // SetLocale registers a locale file in the specified format.
 func (mf *MessageFactory) SetLocale(tag language.Tag, format, path string) {
-	mf.locales[tag] = localeInfo{
+	mf.registerLocale(tag, localeInfo{
 		format: format,
 		path:   path,
+	})
+}
+
+// registerLocale adds a locale to a factory or replaces an existing one.
+func (mf *MessageFactory) registerLocale(tag language.Tag, locale localeInfo) {
+	if _, ok := mf.locales[tag]; !ok {
+		mf.localesOrder = append(mf.localesOrder, tag)
 	}
+	mf.locales[tag] = locale
+
+	mf.dictionaries.remove(tag)
 }
 
-// SetLocales registers locale files in the specified format.
+// SetLocales registers locale files in the specified format. The locales
+// are registered in the alphabetical order of their tags.
 func (mf *MessageFactory) SetLocales(locales map[language.Tag]string, format string) {
-	for tag, path := range locales {
-		mf.SetLocale(tag, format, path)
+	tags := make([]language.Tag, 0, len(locales))
+	for tag := range locales {
+		tags = append(tags, tag)
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].String() < tags[j].String()
+	})
+
+	for _, tag := range tags {
+		mf.SetLocale(tag, format, locales[tag])
 	}
 }
 
 // LoadLocale sets the currently active locale for the messages generated
-// by this factory.
+// by this factory. The locale file is read only if its dictionary is not
+// cached.
 func (mf *MessageFactory) LoadLocale(tag language.Tag) {
 	locale, ok := mf.locales[tag]
 	if !ok {
 		panic(fmt.Sprintf(unknownLocaleTag, tag))
 	}
 
-	loader, ok := g11nLocale.GetLoader(locale.format)
-	if !ok {
-		panic(fmt.Sprintf(unknownFormatMessage, locale.format))
-	}
-
-	mf.dictionary = loader.Load(locale.path)
+	mf.dictionary.Store(mf.cachedDictionary(tag, locale))
 
 	for _, initializer := range mf.stringInitializers {
 		initializer()
 	}
 }
 
+// loadDictionary loads the dictionary of a locale and checks its translations.
+func (mf *MessageFactory) loadDictionary(tag language.Tag, locale localeInfo) *dictionary {
+	dictionary := locale.load(tag)
+	mf.checkMaxLengths(dictionary)
+
+	return dictionary
+}
+
 // Init initializes the message fields of a structure pointer.
 func (mf *MessageFactory) Init(structPtr interface{}) interface{} {
 	mf.initializeStruct(structPtr)
@@ -126,35 +583,113 @@ func (mf *MessageFactory) Init(structPtr interface{}) interface{} {
 }
 
 // messageHandler creates a handler that formats a message based on provided parameters.
-func (mf *MessageFactory) messageHandler(messagePattern, messageKey string, resultType reflect.Type) func([]reflect.Value) []reflect.Value {
+// The message is formatted in the locale of the context passed as first parameter
+// of context-aware message funcs.
+func (mf *MessageFactory) messageHandler(
+	compiledDefault *compiledPattern,
+	messageKey string,
+	funcType reflect.Type) func([]reflect.Value) []reflect.Value {
+
+	resultType := funcType.Out(0)
+	returnsError := funcType.NumOut() == 2
+	escaper := resultEscaper(resultType)
+	withContext := isContextFunc(funcType)
+	variadic := funcType.IsVariadic()
+
 	return func(args []reflect.Value) []reflect.Value {
-		// Extract localized message.
-		if message, ok := mf.dictionary[messageKey]; ok {
-			messagePattern = message
+		var dictionary *dictionary
+		if withContext {
+			ctx, _ := args[0].Interface().(context.Context)
+			dictionary = mf.contextDictionary(ctx)
+			args = args[1:]
+		} else {
+			dictionary = mf.activeDictionary()
+		}
+
+		if variadic {
+			args = expandVariadic(args)
 		}
 
-		// Format message parameters.
-		var formattedParams []interface{}
-		for _, arg := range args {
-			formattedParams = append(formattedParams, formatParam(arg))
+		// Lazy messages are localized when they are rendered.
+		if resultType == messageType {
+			result := mf.lazyMessage(messageKey, args)
+			if returnsError {
+				return []reflect.Value{result, reflect.Zero(errorType)}
+			}
+
+			return []reflect.Value{result}
 		}
 
+		// Extract localized message.
+		messagePattern := dictionary.lookupPattern(messageKey, compiledDefault)
+
 		// Find the result message value.
-		message := fmt.Sprintf(messagePattern, formattedParams...)
-		messageValue := reflect.ValueOf(message)
+		message, err := dictionary.format(messagePattern, args, escaper)
+		if err == nil && !variadic && !messagePattern.referencesAll(len(args)) {
+			err = ErrPlaceholderMismatch
+		}
 
-		// Format message result.
-		resultValue := reflect.New(resultType).Elem()
-		if resultFormatter, ok := resultValue.Interface().(resultFormatter); ok {
-			formattedResult := resultFormatter.G11nResult(message)
-			messageValue = reflect.ValueOf(formattedResult).Convert(resultType)
+		var result reflect.Value
+		if isErrorResult(resultType) {
+			result = mf.localizedError(message, messageKey, compiledDefault, args, resultType)
+		} else {
+			result = formatResult(message, resultType, dictionary.tag, messageKey, args)
+		}
+
+		if !returnsError {
+			return []reflect.Value{result}
+		}
+
+		errValue := reflect.Zero(errorType)
+		if err != nil {
+			errValue = reflect.ValueOf(&FormatError{
+				Key:    messageKey,
+				Locale: dictionary.tag,
+				Err:    err,
+			})
 		}
-		resultValue.Set(messageValue)
 
-		return []reflect.Value{resultValue}
+		return []reflect.Value{result, errValue}
 	}
 }
 
+// expandVariadic replaces the trailing slice of the arguments of a variadic
+// message func with its elements.
+func expandVariadic(args []reflect.Value) []reflect.Value {
+	last := args[len(args)-1]
+
+	expanded := make([]reflect.Value, 0, len(args)-1+last.Len())
+	expanded = append(expanded, args[:len(args)-1]...)
+	for i := 0; i < last.Len(); i++ {
+		expanded = append(expanded, last.Index(i))
+	}
+
+	return expanded
+}
+
+// formatResult converts a formatted message to the result type of its
+// message, applying the result formatter of the type.
+func formatResult(message string, resultType reflect.Type, tag language.Tag, messageKey string, args []reflect.Value) reflect.Value {
+	if resultType == stringType {
+		return reflect.ValueOf(message)
+	}
+
+	messageValue := reflect.ValueOf(message)
+
+	resultValue := reflect.New(resultType).Elem()
+	switch resultFormatter := resultValue.Interface().(type) {
+	case resultInfoFormatter:
+		formattedResult := resultFormatter.G11nResultWithInfo(message, newMessageInfo(tag, messageKey, args))
+		messageValue = reflect.ValueOf(formattedResult)
+	case resultFormatter:
+		formattedResult := resultFormatter.G11nResult(message)
+		messageValue = reflect.ValueOf(formattedResult)
+	}
+	resultValue.Set(messageValue.Convert(resultType))
+
+	return resultValue
+}
+
 // initializeStruct initializes the message fields of a struct pointer.
 func (mf *MessageFactory) initializeStruct(structPtr interface{}) {
 	instance := reflect.Indirect(reflect.ValueOf(structPtr))
@@ -200,20 +735,17 @@ func (mf *MessageFactory) initializeField(
 	if field.Type.Kind() == reflect.String {
 		// Initialize string field.
 
-		message := messagePattern
+		mf.registerMessage(newDescriptor(messageKey, concreteType, field))
 
 		// Format message result.
-		if resultFormatter, ok := instanceField.Interface().(resultFormatter); ok {
-			message = resultFormatter.G11nResult(message)
-		}
+		message := formatResult(messagePattern, field.Type, mf.activeDictionary().tag, messageKey, nil).String()
 
 		mf.stringInitializers = append(mf.stringInitializers, func() {
-			message := messagePattern
+			dictionary := mf.activeDictionary()
 
 			// Extract localized message.
-			if messagePattern, ok := mf.dictionary[messageKey]; ok {
-				message = messagePattern
-			}
+			message := dictionary.lookup(messageKey, messagePattern)
+			message = formatResult(message, field.Type, dictionary.tag, messageKey, nil).String()
 
 			instanceField.SetString(message)
 		})
@@ -223,15 +755,15 @@ func (mf *MessageFactory) initializeField(
 		// Initialize func field.
 
 		// Check if return type of the message func is correct.
-		if field.Type.NumOut() != 1 {
-			panic(fmt.Sprintf(wrongResultsCountMessage, field.Type.NumOut()))
+		if !hasMessageResults(field.Type) {
+			panic(fmt.Sprintf(wrongResultsMessage, resultsString(field.Type)))
 		}
 
-		resultType := field.Type.Out(0)
+		compiledDefault := mf.registerMessage(newDescriptor(messageKey, concreteType, field))
 
 		// Create proxy function for handling the message.
 		messageProxyFunc := reflect.MakeFunc(
-			field.Type, mf.messageHandler(messagePattern, messageKey, resultType))
+			field.Type, mf.messageHandler(compiledDefault, messageKey, field.Type))
 
 		instanceField.Set(messageProxyFunc)
 	}
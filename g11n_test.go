@@ -145,7 +145,7 @@ func TestMessageWithMultipleResults(t *testing.T) {
 		MyLittleSomething func() (string, int) `default:"Oops!"`
 	}
 
-	defer MustPanic(t, "Wrong number of results in a g11n message. Expected 1, got 2.")
+	defer MustPanic(t, "Wrong results of a g11n message. Expected a message and an optional error, got (string, int).")
 
 	New().Init(&M{})
 }
@@ -286,6 +286,72 @@ func TestLocales(t *testing.T) {
 	}
 }
 
+func TestLocalesOrder(t *testing.T) {
+	factory := New()
+
+	factory.SetLocale(language.Spanish, "custom", "")
+	factory.SetLocale(language.Bulgarian, "custom", "")
+	factory.SetLocale(language.Italian, "custom", "")
+	factory.SetLocale(language.Spanish, "custom", "")
+	factory.SetDefaultLocale(language.Italian)
+
+	expected := []language.Tag{language.Italian, language.Spanish, language.Bulgarian}
+	if actual := factory.Locales(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Locales are not correct.\n"+
+			"Expected: %v\n"+
+			"Actual: %v\n", expected, actual)
+	}
+}
+
+func TestMatchLocale(t *testing.T) {
+	factory := New()
+
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian:           "",
+		language.BrazilianPortuguese: "",
+		language.Spanish:             "",
+	}, "custom")
+	factory.SetDefaultLocale(language.Spanish)
+
+	tests := []struct {
+		preferred  string
+		expected   language.Tag
+		confidence language.Confidence
+	}{
+		{"bg", language.Bulgarian, language.Exact},
+		{"pt-PT", language.BrazilianPortuguese, language.High},
+		{"en-GB, bg;q=0.5", language.Bulgarian, language.Exact},
+		{"ja", language.Spanish, language.No},
+		{"", language.Spanish, language.No},
+	}
+
+	for _, test := range tests {
+		preferred, _, _ := language.ParseAcceptLanguage(test.preferred)
+
+		tag, confidence := factory.MatchLocale(preferred...)
+		if tag != test.expected || confidence != test.confidence {
+			t.Errorf("Locale of %q is not correct.\n"+
+				"Expected: %v (%v)\n"+
+				"Actual: %v (%v)\n", test.preferred, test.expected, test.confidence, tag, confidence)
+		}
+	}
+}
+
+func TestMatchLocaleWithoutLocales(t *testing.T) {
+	tag, confidence := New().MatchLocale(language.Bulgarian)
+
+	if tag != language.Und || confidence != language.No {
+		t.Errorf("Locale is not correct. Actual: %v (%v)\n", tag, confidence)
+	}
+}
+
+func TestUnknownDefaultLocale(t *testing.T) {
+	defer MustPanic(t, "Unknown default locale 'bg'.")
+
+	factory := New()
+	factory.SetDefaultLocale(language.Bulgarian)
+}
+
 func TestUnknownLocale(t *testing.T) {
 	defer MustPanic(t, "Unknown locale 'bg'.")
 
@@ -0,0 +1,134 @@
+// Package grpc negotiates the locale of gRPC calls with the locales of a
+// g11n message factory.
+package grpc
+
+import (
+	"context"
+
+	"github.com/sgatev/g11n"
+
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Application constants.
+const (
+	acceptLanguageKey  = "accept-language"
+	contentLanguageKey = "content-language"
+)
+
+// options holds the configuration of the interceptors.
+type options struct {
+	keys       []string
+	confidence language.Confidence
+}
+
+// Option configures the interceptors.
+type Option func(*options)
+
+// WithKeys sets the incoming metadata keys that are read in order for locale
+// preferences. Their values follow the syntax of the Accept-Language HTTP
+// header. The default is accept-language alone.
+func WithKeys(keys ...string) Option {
+	return func(o *options) {
+		o.keys = keys
+	}
+}
+
+// MinConfidence sets the lowest confidence at which a requested locale is
+// accepted as a match of a registered locale. The default is language.Low.
+func MinConfidence(confidence language.Confidence) Option {
+	return func(o *options) {
+		o.confidence = confidence
+	}
+}
+
+// newOptions applies the options of an interceptor to its defaults.
+func newOptions(opts []Option) options {
+	o := options{
+		keys:       []string{acceptLanguageKey},
+		confidence: language.Low,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// UnaryServerInterceptor returns a server interceptor that negotiates the
+// locale of unary calls. The negotiated locale is stored in the context
+// passed to the handler, where context-aware message funcs find it, and is
+// sent to the client in the content-language header.
+func UnaryServerInterceptor(mf *g11n.MessageFactory, opts ...Option) grpc.UnaryServerInterceptor {
+	o := newOptions(opts)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		tag, ok := negotiate(ctx, mf, o)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		if err := grpc.SetHeader(ctx, metadata.Pairs(contentLanguageKey, tag.String())); err != nil {
+			return nil, err
+		}
+
+		return handler(g11n.WithLocale(ctx, tag), req)
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor that negotiates the
+// locale of streaming calls the same way as UnaryServerInterceptor.
+func StreamServerInterceptor(mf *g11n.MessageFactory, opts ...Option) grpc.StreamServerInterceptor {
+	o := newOptions(opts)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		tag, ok := negotiate(ss.Context(), mf, o)
+		if !ok {
+			return handler(srv, ss)
+		}
+
+		if err := ss.SetHeader(metadata.Pairs(contentLanguageKey, tag.String())); err != nil {
+			return err
+		}
+
+		return handler(srv, &localizedStream{
+			ServerStream: ss,
+			ctx:          g11n.WithLocale(ss.Context(), tag),
+		})
+	}
+}
+
+// localizedStream is a server stream whose context carries a locale.
+type localizedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context returns the context of the stream.
+func (ls *localizedStream) Context() context.Context {
+	return ls.ctx
+}
+
+// negotiate returns the first registered locale requested by the incoming
+// metadata of a call, falling back to the default locale.
+func negotiate(ctx context.Context, mf *g11n.MessageFactory, o options) (language.Tag, bool) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	for _, key := range o.keys {
+		for _, value := range md.Get(key) {
+			requested, _, err := language.ParseAcceptLanguage(value)
+			if err != nil || len(requested) == 0 {
+				continue
+			}
+
+			tag, confidence := mf.MatchLocale(requested...)
+			if confidence != language.No && confidence >= o.confidence {
+				return tag, true
+			}
+		}
+	}
+
+	return mf.DefaultLocale()
+}
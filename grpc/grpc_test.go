@@ -0,0 +1,225 @@
+package grpc_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/grpc"
+	. "github.com/sgatev/g11n/test"
+)
+
+func testMessage(t *testing.T, actual, expected string) {
+	if actual != expected {
+		t.Errorf("Message is not the same as expected.\n"+
+			"\tActual: %v\n"+
+			"\tExpected: %v\n", actual, expected)
+	}
+}
+
+type M struct {
+	Hello func(context.Context, string) string `default:"Hi %v!"`
+}
+
+// greeter is a test service that greets in the locale of a call.
+type greeter struct {
+	m *M
+}
+
+func (g *greeter) hello(ctx context.Context, name string) *wrapperspb.StringValue {
+	return wrapperspb.String(g.m.Hello(ctx, name))
+}
+
+var greeterService = grpc.ServiceDesc{
+	ServiceName: "test.Greeter",
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Hello",
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			in := &wrapperspb.StringValue{}
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return srv.(*greeter).hello(ctx, req.(*wrapperspb.StringValue).GetValue()), nil
+			}
+			if interceptor == nil {
+				return handler(ctx, in)
+			}
+
+			return interceptor(ctx, in, &grpc.UnaryServerInfo{
+				Server:     srv,
+				FullMethod: "/test.Greeter/Hello",
+			}, handler)
+		},
+	}},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "HelloStream",
+		ServerStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			in := &wrapperspb.StringValue{}
+			if err := stream.RecvMsg(in); err != nil {
+				return err
+			}
+
+			return stream.SendMsg(srv.(*greeter).hello(stream.Context(), in.GetValue()))
+		},
+	}},
+}
+
+// dial starts an in-process server with the interceptors of a factory and
+// connects to it.
+func dial(t *testing.T, factory *MessageFactory, opts ...Option) *grpc.ClientConn {
+	listener := bufconn.Listen(1 << 20)
+
+	server := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(factory, opts...)),
+		grpc.StreamInterceptor(StreamServerInterceptor(factory, opts...)))
+	server.RegisterService(&greeterService, &greeter{m: factory.Init(&M{}).(*M)})
+
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn
+}
+
+// hello calls the unary method and returns the greeting and the
+// content-language header.
+func hello(t *testing.T, conn *grpc.ClientConn, md metadata.MD) (string, string) {
+	ctx := metadata.NewOutgoingContext(context.Background(), md)
+
+	var header metadata.MD
+	out := &wrapperspb.StringValue{}
+	if err := conn.Invoke(ctx, "/test.Greeter/Hello", wrapperspb.String("Ivan"), out, grpc.Header(&header)); err != nil {
+		t.Fatalf("Call failed: %v", err)
+	}
+
+	return out.GetValue(), contentLanguage(header)
+}
+
+func contentLanguage(header metadata.MD) string {
+	if values := header.Get("content-language"); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"M.Hello": "Здравей %v!"}`),
+		language.Spanish:   TempFile(`{"M.Hello": "¡Hola %v!"}`),
+	}, "json")
+
+	conn := dial(t, factory)
+
+	message, contentLang := hello(t, conn, metadata.Pairs("accept-language", "fr, bg;q=0.8"))
+
+	testMessage(t, message, "Здравей Ivan!")
+	testMessage(t, contentLang, "bg")
+}
+
+func TestUnaryServerInterceptorNoMatch(t *testing.T) {
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"M.Hello": "Здравей %v!"}`),
+		language.Spanish:   TempFile(`{"M.Hello": "¡Hola %v!"}`),
+	}, "json")
+
+	conn := dial(t, factory)
+
+	message, contentLang := hello(t, conn, metadata.Pairs("accept-language", "ja"))
+
+	testMessage(t, message, "Hi Ivan!")
+	testMessage(t, contentLang, "")
+}
+
+func TestUnaryServerInterceptorDefaultLocale(t *testing.T) {
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"M.Hello": "Здравей %v!"}`),
+		language.Spanish:   TempFile(`{"M.Hello": "¡Hola %v!"}`),
+	}, "json")
+	factory.SetDefaultLocale(language.Spanish)
+
+	conn := dial(t, factory)
+
+	message, contentLang := hello(t, conn, metadata.MD{})
+
+	testMessage(t, message, "¡Hola Ivan!")
+	testMessage(t, contentLang, "es")
+}
+
+func TestUnaryServerInterceptorKeys(t *testing.T) {
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"M.Hello": "Здравей %v!"}`),
+		language.Spanish:   TempFile(`{"M.Hello": "¡Hola %v!"}`),
+	}, "json")
+
+	conn := dial(t, factory, WithKeys("x-locale", "accept-language"))
+
+	message, _ := hello(t, conn, metadata.Pairs(
+		"accept-language", "bg",
+		"x-locale", "es"))
+
+	testMessage(t, message, "¡Hola Ivan!")
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"M.Hello": "Здравей %v!"}`),
+		language.Spanish:   TempFile(`{"M.Hello": "¡Hola %v!"}`),
+	}, "json")
+
+	conn := dial(t, factory)
+
+	ctx := metadata.NewOutgoingContext(context.Background(),
+		metadata.Pairs("accept-language", "es-MX"))
+
+	stream, err := conn.NewStream(ctx, &greeterService.Streams[0], "/test.Greeter/HelloStream")
+	if err != nil {
+		t.Fatalf("Stream failed: %v", err)
+	}
+
+	if err := stream.SendMsg(wrapperspb.String("Ivan")); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+	if err := stream.CloseSend(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	out := &wrapperspb.StringValue{}
+	if err := stream.RecvMsg(out); err != nil {
+		t.Fatalf("Receive failed: %v", err)
+	}
+
+	header, err := stream.Header()
+	if err != nil {
+		t.Fatalf("Header failed: %v", err)
+	}
+
+	testMessage(t, out.GetValue(), "¡Hola Ivan!")
+	testMessage(t, contentLanguage(header), "es")
+}
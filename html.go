@@ -0,0 +1,92 @@
+package g11n
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/message"
+)
+
+// paramEscaper represents a result type whose messages escape their
+// parameters before substituting them in the message.
+type paramEscaper interface {
+
+	// G11nEscape escapes a formatted parameter of a g11n message.
+	G11nEscape(formattedParam string) string
+}
+
+// htmlResult represents a result type that holds safe HTML.
+type htmlResult interface {
+	HTML() template.HTML
+}
+
+// SafeHTML is a message result type for HTML messages. The parameters of its
+// messages are HTML-escaped, while the markup of the message patterns is
+// kept intact.
+type SafeHTML string
+
+// G11nEscape escapes a parameter of a SafeHTML message.
+func (SafeHTML) G11nEscape(formattedParam string) string {
+	return html.EscapeString(formattedParam)
+}
+
+// HTML returns the message as HTML content that html/template does not escape.
+func (sh SafeHTML) HTML() template.HTML {
+	return template.HTML(sh)
+}
+
+// htmlType is the reflected type of template.HTML.
+var htmlType = reflect.TypeOf(template.HTML(""))
+
+// resultEscaper returns the escaper of a message result type, if any. The
+// parameters of template.HTML messages are escaped like those of SafeHTML,
+// since html/template trusts their content.
+func resultEscaper(resultType reflect.Type) paramEscaper {
+	if resultType == htmlType {
+		return SafeHTML("")
+	}
+
+	escaper, _ := reflect.Zero(resultType).Interface().(paramEscaper)
+	return escaper
+}
+
+// escapedParam is a message parameter that is escaped after it is formatted.
+type escapedParam struct {
+	value   interface{}
+	printer *message.Printer
+	escaper paramEscaper
+}
+
+// Format formats the parameter as requested by its verb and escapes it.
+func (ep escapedParam) Format(state fmt.State, verb rune) {
+	formatted := sprintf(ep.printer, formatDirective(state, verb), ep.value)
+	io.WriteString(state, ep.escaper.G11nEscape(formatted))
+}
+
+// formatDirective rebuilds the formatting directive of a verb with the
+// flags, width and precision of a formatter state, e.g. %-8.2f.
+func formatDirective(state fmt.State, verb rune) string {
+	var directive strings.Builder
+
+	directive.WriteByte('%')
+	for _, flag := range "+-# 0" {
+		if state.Flag(int(flag)) {
+			directive.WriteRune(flag)
+		}
+	}
+	if width, ok := state.Width(); ok {
+		directive.WriteString(strconv.Itoa(width))
+	}
+	if precision, ok := state.Precision(); ok {
+		directive.WriteByte('.')
+		directive.WriteString(strconv.Itoa(precision))
+	}
+	directive.WriteRune(verb)
+
+	return directive.String()
+}
@@ -0,0 +1,74 @@
+package g11n_test
+
+import (
+	"html/template"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+type HTMLMessages struct {
+	Hello   func(string) SafeHTML      `default:"Hi <b>%v</b>!"`
+	Padded  func(string) SafeHTML      `default:"<i>[%5s]</i>"`
+	Total   func(string, int) SafeHTML `default:"<em>%v</em> owes %d"`
+	Price   func(float64) SafeHTML     `default:"<b>[%+-8.2f]</b>"`
+	Trusted func(string) template.HTML `default:"Hi <b>%v</b>!"`
+}
+
+func TestSafeHTMLEscapesParams(t *testing.T) {
+	m := New().Init(&HTMLMessages{}).(*HTMLMessages)
+
+	testMessage(t,
+		string(m.Hello(`<script>alert("x")</script>`)),
+		`Hi <b>&lt;script&gt;alert(&#34;x&#34;)&lt;/script&gt;</b>!`)
+	testMessage(t,
+		string(m.Padded("&")),
+		`<i>[    &amp;]</i>`)
+	testMessage(t,
+		string(m.Price(3.14159)),
+		`<b>[+3.14   ]</b>`)
+}
+
+func TestTemplateHTMLEscapesParams(t *testing.T) {
+	m := New().Init(&HTMLMessages{}).(*HTMLMessages)
+
+	testMessage(t,
+		string(m.Trusted("<script>x</script>")),
+		"Hi <b>&lt;script&gt;x&lt;/script&gt;</b>!")
+}
+
+func TestSafeHTMLLocalized(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.German, "json", TempFile(`
+	{
+	  "HTMLMessages.Total": "<strong>%v</strong> schuldet %d"
+	}
+`))
+	factory.LoadLocale(language.German)
+
+	m := factory.Init(&HTMLMessages{}).(*HTMLMessages)
+
+	testMessage(t,
+		string(m.Total("Tom & Jerry", 1234)),
+		"<strong>Tom &amp; Jerry</strong> schuldet 1.234")
+}
+
+func TestSafeHTMLTemplate(t *testing.T) {
+	factory := New()
+	factory.Init(&HTMLMessages{})
+
+	testMessage(t,
+		executeHTML(t, factory, `<p>{{t "HTMLMessages.Hello" .}}</p>`, "<Bob>"),
+		"<p>Hi <b>&lt;Bob&gt;</b>!</p>")
+}
+
+func TestSafeHTMLAsHTML(t *testing.T) {
+	m := New().Init(&HTMLMessages{}).(*HTMLMessages)
+
+	testMessage(t,
+		string(m.Hello("<Bob>").HTML()),
+		"Hi <b>&lt;Bob&gt;</b>!")
+}
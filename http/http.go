@@ -9,12 +9,15 @@ import (
 )
 
 // SetLocale sets the locale of a MessageFactory from HTTP Request value.
+// The default locale of the factory is loaded if none of the registered
+// locales matches the Accept-Language header.
 func SetLocale(mf *g11n.MessageFactory, r *http.Request) {
-	acceptLanguage := r.Header.Get("Accept-Language")
-	preferred, _, _ := language.ParseAcceptLanguage(acceptLanguage)
+	preferred, _, _ := language.ParseAcceptLanguage(r.Header.Get(acceptLanguageHeader))
 
-	var matcher = language.NewMatcher(mf.Locales())
-	tag, _, _ := matcher.Match(preferred...)
+	tag, _ := mf.MatchLocale(preferred...)
+	if tag == language.Und {
+		return
+	}
 
 	mf.LoadLocale(tag)
 }
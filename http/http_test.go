@@ -54,3 +54,53 @@ func TestSetLocaleFromRequest(t *testing.T) {
 		string(m.MyLittleSomething()),
 		`котка`)
 }
+
+func TestSetLocaleFallbackToDefault(t *testing.T) {
+	type M struct {
+		MyLittleSomething func() string `default:"cat"`
+	}
+
+	factory := New()
+
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"M.MyLittleSomething": "котка"}`),
+		language.Spanish:   TempFile(`{"M.MyLittleSomething": "gato"}`),
+	}, "json")
+	factory.SetDefaultLocale(language.Spanish)
+
+	m := factory.Init(&M{}).(*M)
+
+	for i := 0; i < 10; i++ {
+		r, _ := http.NewRequest("GET", "https://golang.org", nil)
+		r.Header.Add("Accept-Language", "ja")
+
+		SetLocale(factory, r)
+
+		testMessage(t, m.MyLittleSomething(), "gato")
+	}
+}
+
+func TestSetLocaleRegionExtension(t *testing.T) {
+	type M struct {
+		MyLittleSomething func() string `default:"cat"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.English, "json", TempFile(`{"M.MyLittleSomething": "kitty"}`))
+
+	r, _ := http.NewRequest("GET", "https://golang.org", nil)
+	r.Header.Add("Accept-Language", "en-GB")
+
+	SetLocale(factory, r)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t, m.MyLittleSomething(), "kitty")
+}
+
+func TestSetLocaleWithoutLocales(t *testing.T) {
+	r, _ := http.NewRequest("GET", "https://golang.org", nil)
+	r.Header.Add("Accept-Language", "bg")
+
+	SetLocale(New(), r)
+}
@@ -0,0 +1,298 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/sgatev/g11n"
+
+	"golang.org/x/text/language"
+)
+
+// Application constants.
+const (
+	acceptLanguageHeader  = "Accept-Language"
+	contentLanguageHeader = "Content-Language"
+	cookieHeader          = "Cookie"
+	varyHeader            = "Vary"
+)
+
+// Strategy extracts the locales preferred by a request in order of preference.
+type Strategy interface {
+
+	// Locales returns the locales requested by a request.
+	Locales(r *http.Request) []language.Tag
+}
+
+// StrategyFunc is an adapter that allows the use of ordinary funcs as strategies.
+type StrategyFunc func(r *http.Request) []language.Tag
+
+// Locales calls f(r).
+func (f StrategyFunc) Locales(r *http.Request) []language.Tag {
+	return f(r)
+}
+
+// varier is implemented by strategies whose result depends on a request header.
+type varier interface {
+	vary() string
+}
+
+// rewriter is implemented by strategies that remove the locale from the
+// request once it is negotiated.
+type rewriter interface {
+	rewrite(r *http.Request) *http.Request
+}
+
+// headerStrategy reads the locales from a request header.
+type headerStrategy struct {
+	name string
+}
+
+// Header returns a strategy that reads a locale from a request header.
+func Header(name string) Strategy {
+	return headerStrategy{name: name}
+}
+
+// Locales implements Strategy.
+func (s headerStrategy) Locales(r *http.Request) []language.Tag {
+	return parseTags(r.Header.Get(s.name))
+}
+
+func (s headerStrategy) vary() string {
+	return http.CanonicalHeaderKey(s.name)
+}
+
+// acceptLanguageStrategy reads the locales from the Accept-Language header.
+type acceptLanguageStrategy struct{}
+
+// AcceptLanguage returns a strategy that reads the locales from the
+// Accept-Language header of a request.
+func AcceptLanguage() Strategy {
+	return acceptLanguageStrategy{}
+}
+
+// Locales implements Strategy.
+func (acceptLanguageStrategy) Locales(r *http.Request) []language.Tag {
+	tags, _, _ := language.ParseAcceptLanguage(r.Header.Get(acceptLanguageHeader))
+	return tags
+}
+
+func (acceptLanguageStrategy) vary() string {
+	return acceptLanguageHeader
+}
+
+// cookieStrategy reads the locale from a cookie.
+type cookieStrategy struct {
+	name string
+}
+
+// Cookie returns a strategy that reads a locale from a cookie.
+func Cookie(name string) Strategy {
+	return cookieStrategy{name: name}
+}
+
+// Locales implements Strategy.
+func (s cookieStrategy) Locales(r *http.Request) []language.Tag {
+	cookie, err := r.Cookie(s.name)
+	if err != nil {
+		return nil
+	}
+
+	return parseTags(cookie.Value)
+}
+
+func (cookieStrategy) vary() string {
+	return cookieHeader
+}
+
+// Query returns a strategy that reads a locale from a query parameter.
+func Query(name string) Strategy {
+	return StrategyFunc(func(r *http.Request) []language.Tag {
+		return parseTags(r.URL.Query().Get(name))
+	})
+}
+
+// pathPrefixStrategy reads the locale from the first segment of the URL path.
+type pathPrefixStrategy struct{}
+
+// PathPrefix returns a strategy that reads a locale from the first segment of
+// the URL path, e.g. /de/about. The segment is removed from the path of the
+// request passed to the next handler if its locale is negotiated.
+func PathPrefix() Strategy {
+	return pathPrefixStrategy{}
+}
+
+// Locales implements Strategy.
+func (pathPrefixStrategy) Locales(r *http.Request) []language.Tag {
+	prefix, _ := splitPath(r.URL.Path)
+	return parseTags(prefix)
+}
+
+func (pathPrefixStrategy) rewrite(r *http.Request) *http.Request {
+	_, rest := splitPath(r.URL.Path)
+
+	rewritten := r.Clone(r.Context())
+	rewritten.URL.Path = rest
+	rewritten.URL.RawPath = ""
+
+	return rewritten
+}
+
+// splitPath splits the first segment of a URL path from the rest of the path.
+func splitPath(path string) (string, string) {
+	path = strings.TrimPrefix(path, "/")
+
+	if i := strings.IndexByte(path, '/'); i >= 0 {
+		return path[:i], path[i:]
+	}
+
+	return path, "/"
+}
+
+// parseTags parses a single locale, ignoring malformed values.
+func parseTags(value string) []language.Tag {
+	if value == "" {
+		return nil
+	}
+
+	tag, err := language.Parse(value)
+	if err != nil {
+		return nil
+	}
+
+	return []language.Tag{tag}
+}
+
+// options holds the configuration of a middleware.
+type options struct {
+	strategies []Strategy
+	cookie     *http.Cookie
+	confidence language.Confidence
+}
+
+// Option configures a middleware.
+type Option func(*options)
+
+// WithStrategies sets the strategies of a middleware in the order they are
+// tried. The default is AcceptLanguage alone.
+func WithStrategies(strategies ...Strategy) Option {
+	return func(o *options) {
+		o.strategies = strategies
+	}
+}
+
+// MinConfidence sets the lowest confidence at which a locale requested by a
+// strategy is accepted as a match of a registered locale. The default is
+// language.Low.
+func MinConfidence(confidence language.Confidence) Option {
+	return func(o *options) {
+		o.confidence = confidence
+	}
+}
+
+// PersistCookie stores the negotiated locale in a cookie that is a copy of
+// the provided one with the locale as value. A Cookie strategy with the same
+// name reads it back on later requests.
+func PersistCookie(cookie http.Cookie) Option {
+	return func(o *options) {
+		o.cookie = &cookie
+	}
+}
+
+// Middleware returns a middleware that negotiates the locale of each request
+// with the registered locales of a message factory. The strategies are tried
+// in order and the first one that requests a supported locale wins. The
+// default locale of the factory is used if no strategy succeeds.
+//
+// The negotiated locale is stored in the request context, where
+// g11n.LocaleFromContext and context-aware message funcs find it, and is
+// reported in the Content-Language response header. The factory itself is
+// not modified.
+func Middleware(mf *g11n.MessageFactory, opts ...Option) func(http.Handler) http.Handler {
+	o := options{
+		strategies: []Strategy{AcceptLanguage()},
+		confidence: language.Low,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var vary []string
+	for _, strategy := range o.strategies {
+		if v, ok := strategy.(varier); ok {
+			vary = appendUnique(vary, v.vary())
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, v := range vary {
+				w.Header().Add(varyHeader, v)
+			}
+
+			tag, strategy, ok := negotiate(mf, o, r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if rewriter, ok := strategy.(rewriter); ok {
+				r = rewriter.rewrite(r)
+			}
+
+			w.Header().Set(contentLanguageHeader, tag.String())
+
+			if o.cookie != nil {
+				persist(w, r, *o.cookie, tag)
+			}
+
+			next.ServeHTTP(w, r.WithContext(g11n.WithLocale(r.Context(), tag)))
+		})
+	}
+}
+
+// Locale returns the locale negotiated for a request by Middleware.
+func Locale(r *http.Request) (language.Tag, bool) {
+	return g11n.LocaleFromContext(r.Context())
+}
+
+// negotiate returns the first registered locale requested by the strategies
+// and the strategy that requested it, falling back to the default locale.
+func negotiate(mf *g11n.MessageFactory, o options, r *http.Request) (language.Tag, Strategy, bool) {
+	for _, strategy := range o.strategies {
+		requested := strategy.Locales(r)
+		if len(requested) == 0 {
+			continue
+		}
+
+		tag, confidence := mf.MatchLocale(requested...)
+		if confidence != language.No && confidence >= o.confidence {
+			return tag, strategy, true
+		}
+	}
+
+	tag, ok := mf.DefaultLocale()
+
+	return tag, nil, ok
+}
+
+// persist stores a locale in a cookie unless the request already carries it.
+func persist(w http.ResponseWriter, r *http.Request, cookie http.Cookie, tag language.Tag) {
+	if current, err := r.Cookie(cookie.Name); err == nil && current.Value == tag.String() {
+		return
+	}
+
+	cookie.Value = tag.String()
+	http.SetCookie(w, &cookie)
+}
+
+// appendUnique appends a value to a slice unless it is already present.
+func appendUnique(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
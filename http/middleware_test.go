@@ -0,0 +1,192 @@
+package http_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/http"
+	. "github.com/sgatev/g11n/test"
+)
+
+type middlewareMessages struct {
+	Animal func(context.Context) string `default:"cat"`
+}
+
+// serve sends a request through the middleware and returns the response
+// together with the message and the path seen by the handler.
+func serve(r *http.Request, opts ...Option) (*httptest.ResponseRecorder, string, string) {
+	return serveFactory(newMiddlewareFactory(), r, opts...)
+}
+
+// newMiddlewareFactory creates a factory with Bulgarian and Spanish locales.
+func newMiddlewareFactory() *MessageFactory {
+	factory := New()
+
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"middlewareMessages.Animal": "котка"}`),
+		language.Spanish:   TempFile(`{"middlewareMessages.Animal": "gato"}`),
+	}, "json")
+
+	return factory
+}
+
+// serveFactory sends a request through the middleware of a factory.
+func serveFactory(factory *MessageFactory, r *http.Request, opts ...Option) (*httptest.ResponseRecorder, string, string) {
+
+	m := factory.Init(&middlewareMessages{}).(*middlewareMessages)
+
+	var message, path string
+	handler := Middleware(factory, opts...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		message = m.Animal(r.Context())
+		path = r.URL.Path
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	return w, message, path
+}
+
+func TestMiddlewareAcceptLanguage(t *testing.T) {
+	r := httptest.NewRequest("GET", "/animal", nil)
+	r.Header.Set("Accept-Language", "fr, es;q=0.8")
+
+	w, message, _ := serve(r)
+
+	testMessage(t, message, "gato")
+	testMessage(t, w.Header().Get("Content-Language"), "es")
+	testMessage(t, w.Header().Get("Vary"), "Accept-Language")
+}
+
+func TestMiddlewarePathPrefix(t *testing.T) {
+	r := httptest.NewRequest("GET", "/bg/animal", nil)
+
+	w, message, path := serve(r, WithStrategies(PathPrefix(), AcceptLanguage()))
+
+	testMessage(t, message, "котка")
+	testMessage(t, path, "/animal")
+	testMessage(t, w.Header().Get("Content-Language"), "bg")
+}
+
+func TestMiddlewarePathPrefixUnsupported(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/animal", nil)
+	r.Header.Set("Accept-Language", "es")
+
+	_, message, path := serve(r, WithStrategies(PathPrefix(), AcceptLanguage()))
+
+	testMessage(t, message, "gato")
+	testMessage(t, path, "/api/animal")
+}
+
+func TestMiddlewareQuery(t *testing.T) {
+	r := httptest.NewRequest("GET", "/animal?lang=bg", nil)
+	r.Header.Set("Accept-Language", "es")
+
+	_, message, _ := serve(r, WithStrategies(Query("lang"), AcceptLanguage()))
+
+	testMessage(t, message, "котка")
+}
+
+func TestMiddlewareCookie(t *testing.T) {
+	r := httptest.NewRequest("GET", "/animal", nil)
+	r.AddCookie(&http.Cookie{Name: "lang", Value: "es"})
+
+	w, message, _ := serve(r, WithStrategies(Cookie("lang"), AcceptLanguage()))
+
+	testMessage(t, message, "gato")
+	testMessage(t, w.Header()["Vary"][0], "Cookie")
+	testMessage(t, w.Header()["Vary"][1], "Accept-Language")
+}
+
+func TestMiddlewareHeader(t *testing.T) {
+	r := httptest.NewRequest("GET", "/animal", nil)
+	r.Header.Set("X-Locale", "bg")
+
+	w, message, _ := serve(r, WithStrategies(Header("x-locale")))
+
+	testMessage(t, message, "котка")
+	testMessage(t, w.Header().Get("Vary"), "X-Locale")
+}
+
+func TestMiddlewareNoMatch(t *testing.T) {
+	r := httptest.NewRequest("GET", "/animal", nil)
+	r.Header.Set("Accept-Language", "ja")
+
+	w, message, _ := serve(r)
+
+	testMessage(t, message, "cat")
+	testMessage(t, w.Header().Get("Content-Language"), "")
+}
+
+func TestMiddlewareDefaultLocale(t *testing.T) {
+	factory := newMiddlewareFactory()
+	factory.SetDefaultLocale(language.Bulgarian)
+
+	r := httptest.NewRequest("GET", "/animal", nil)
+	r.Header.Set("Accept-Language", "ja")
+
+	w, message, _ := serveFactory(factory, r)
+
+	testMessage(t, message, "котка")
+	testMessage(t, w.Header().Get("Content-Language"), "bg")
+}
+
+func TestMiddlewareMinConfidence(t *testing.T) {
+	factory := newMiddlewareFactory()
+	factory.SetDefaultLocale(language.Bulgarian)
+
+	r := httptest.NewRequest("GET", "/animal", nil)
+	r.Header.Set("Accept-Language", "es-419")
+
+	_, message, _ := serveFactory(factory, r)
+	testMessage(t, message, "gato")
+
+	_, message, _ = serveFactory(factory, r, MinConfidence(language.Exact))
+	testMessage(t, message, "котка")
+}
+
+func TestMiddlewarePersistCookie(t *testing.T) {
+	r := httptest.NewRequest("GET", "/animal?lang=bg", nil)
+
+	w, _, _ := serve(r,
+		WithStrategies(Cookie("lang"), Query("lang")),
+		PersistCookie(http.Cookie{Name: "lang", Path: "/"}))
+
+	testMessage(t, w.Header().Get("Set-Cookie"), "lang=bg; Path=/")
+
+	r = httptest.NewRequest("GET", "/animal", nil)
+	r.AddCookie(&http.Cookie{Name: "lang", Value: "bg"})
+
+	w, message, _ := serve(r,
+		WithStrategies(Cookie("lang"), Query("lang")),
+		PersistCookie(http.Cookie{Name: "lang", Path: "/"}))
+
+	testMessage(t, message, "котка")
+	testMessage(t, w.Header().Get("Set-Cookie"), "")
+}
+
+func TestLocale(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`{}`))
+
+	var tag language.Tag
+	var ok bool
+	handler := Middleware(factory)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tag, ok = Locale(r)
+	}))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Language", "bg-BG")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	if !ok || tag != language.Bulgarian {
+		t.Errorf("Locale is not the same as expected.\n"+
+			"\tActual: %v\n"+
+			"\tExpected: %v\n", tag, language.Bulgarian)
+	}
+}
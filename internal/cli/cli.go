@@ -0,0 +1,99 @@
+// Package cli contains helpers shared by the g11n command-line tools.
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/text/language"
+	"golang.org/x/tools/go/packages"
+)
+
+// Error message patterns.
+const (
+	wrongLocaleFlagMessage = "Wrong locale '%v'. Expected tag=path."
+	packageErrorsMessage   = "Packages %v contain errors."
+)
+
+// LocaleFile describes a locale file passed on the command line.
+type LocaleFile struct {
+	Tag    language.Tag
+	Path   string
+	Format string
+}
+
+// LocaleFlags collects repeated -locale tag=path flags.
+type LocaleFlags []LocaleFile
+
+// String returns the flag value as passed on the command line.
+func (lf *LocaleFlags) String() string {
+	var values []string
+	for _, file := range *lf {
+		values = append(values, file.Tag.String()+"="+file.Path)
+	}
+
+	return strings.Join(values, ",")
+}
+
+// Set parses a tag=path flag value.
+func (lf *LocaleFlags) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 {
+		return fmt.Errorf(wrongLocaleFlagMessage, value)
+	}
+
+	tag, err := language.Parse(parts[0])
+	if err != nil {
+		return err
+	}
+
+	*lf = append(*lf, LocaleFile{
+		Tag:    tag,
+		Path:   parts[1],
+		Format: FormatFromExtension(parts[1]),
+	})
+
+	return nil
+}
+
+// SetFormat overrides the format of all locale files unless format is empty.
+func (lf LocaleFlags) SetFormat(format string) {
+	if format == "" {
+		return
+	}
+
+	for i := range lf {
+		lf[i].Format = format
+	}
+}
+
+// FormatFromExtension infers the locale format of a file from its extension.
+func FormatFromExtension(path string) string {
+	extension := strings.TrimPrefix(filepath.Ext(path), ".")
+	if extension == "yml" {
+		return "yaml"
+	}
+
+	return extension
+}
+
+// LoadPackages type-checks the packages matching patterns relative to a directory.
+func LoadPackages(dir string, patterns ...string) ([]*packages.Package, error) {
+	config := &packages.Config{
+		Mode: packages.NeedName | packages.NeedImports | packages.NeedDeps |
+			packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir: dir,
+	}
+
+	pkgs, err := packages.Load(config, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	if packages.PrintErrors(pkgs) > 0 {
+		return nil, fmt.Errorf(packageErrorsMessage, strings.Join(patterns, " "))
+	}
+
+	return pkgs, nil
+}
@@ -0,0 +1,325 @@
+// Package scan discovers g11n message structs in type-checked Go code.
+//
+// The message keys and default patterns are computed exactly as the
+// g11n MessageFactory computes them at runtime, which allows tools to work
+// with message structs without running the program.
+package scan
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"strconv"
+)
+
+// Application constants.
+const (
+	defaultMessageTag = "default"
+	descriptionTag    = "desc"
+	maxLengthTag      = "maxlen"
+	factoryPackage    = "github.com/sgatev/g11n"
+	factoryType       = "MessageFactory"
+	factoryInitMethod = "Init"
+
+	// MessagesMarker marks the declaration of a message struct that is not
+	// passed to MessageFactory.Init in the scanned packages.
+	MessagesMarker = "//g11n:messages"
+)
+
+// FieldKind classifies the fields of a message struct.
+type FieldKind int
+
+// Field kinds.
+const (
+	// InvalidField is a field that g11n is not able to initialize.
+	InvalidField FieldKind = iota
+
+	// StringField is a message field of a string kind.
+	StringField
+
+	// FuncField is a message field of a func kind.
+	FuncField
+
+	// EmbeddedField is an embedded pointer to another message struct.
+	EmbeddedField
+)
+
+// Field represents a single field of a message struct.
+type Field struct {
+	Var  *types.Var
+	Kind FieldKind
+	Tag  reflect.StructTag
+
+	// Key is the key of the message in the locale files.
+	Key string
+
+	// Default is the default pattern of the message.
+	Default string
+
+	// Description tells translators where and how the message is used.
+	Description string
+
+	// MaxLength is the maximum number of characters of a translation, or 0
+	// if the length is not limited or the maxlen tag is malformed.
+	MaxLength int
+
+	// Signature is the type of a FuncField.
+	Signature *types.Signature
+
+	// Embedded is the message struct of an EmbeddedField.
+	Embedded *Struct
+}
+
+// Name returns the name of the field.
+func (f *Field) Name() string {
+	return f.Var.Name()
+}
+
+// Params returns the parameters of a FuncField that are substituted in the
+// message, leaving out a leading context.Context.
+func (f *Field) Params() []*types.Var {
+	if f.Signature == nil {
+		return nil
+	}
+
+	var params []*types.Var
+	for i := 0; i < f.Signature.Params().Len(); i++ {
+		param := f.Signature.Params().At(i)
+		if i == 0 && IsContext(param.Type()) {
+			continue
+		}
+		params = append(params, param)
+	}
+
+	return params
+}
+
+// ReturnsError reports whether a FuncField returns an error after its
+// message, i.e. has results of shape (T, error).
+func (f *Field) ReturnsError() bool {
+	if f.Signature == nil || f.Signature.Results().Len() != 2 {
+		return false
+	}
+
+	return types.Identical(f.Signature.Results().At(1).Type(), types.Universe.Lookup("error").Type())
+}
+
+// IsContext reports whether a type is context.Context.
+func IsContext(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	object := named.Obj()
+
+	return object.Name() == "Context" &&
+		object.Pkg() != nil &&
+		object.Pkg().Path() == "context"
+}
+
+// Struct represents a message struct.
+type Struct struct {
+	Named  *types.Named
+	Fields []*Field
+}
+
+// Name returns the name of the message struct type.
+func (s *Struct) Name() string {
+	return s.Named.Obj().Name()
+}
+
+// Messages returns the message fields of a struct and of all structs
+// embedded in it.
+func (s *Struct) Messages() []*Field {
+	var messages []*Field
+
+	for _, field := range s.Fields {
+		switch field.Kind {
+		case StringField, FuncField:
+			messages = append(messages, field)
+		case EmbeddedField:
+			messages = append(messages, field.Embedded.Messages()...)
+		}
+	}
+
+	return messages
+}
+
+// Load builds the message struct of a named type. The result is nil if
+// the underlying type is not a struct.
+func Load(named *types.Named) *Struct {
+	return load(named, map[*types.Named]bool{})
+}
+
+// load builds the message struct of a named type, guarding against
+// recursively embedded structs.
+func load(named *types.Named, visited map[*types.Named]bool) *Struct {
+	structType, ok := named.Underlying().(*types.Struct)
+	if !ok || visited[named] {
+		return nil
+	}
+	visited[named] = true
+
+	result := &Struct{Named: named}
+
+	for i := 0; i < structType.NumFields(); i++ {
+		fieldVar := structType.Field(i)
+		field := &Field{
+			Var: fieldVar,
+			Tag: reflect.StructTag(structType.Tag(i)),
+		}
+
+		if fieldVar.Anonymous() {
+			if embedded := embeddedStruct(fieldVar.Type()); embedded != nil {
+				field.Embedded = load(embedded, visited)
+			}
+			if field.Embedded != nil {
+				field.Kind = EmbeddedField
+			}
+		} else {
+			field.Key = named.Obj().Name() + "." + fieldVar.Name()
+			field.Default = field.Tag.Get(defaultMessageTag)
+			field.Description = field.Tag.Get(descriptionTag)
+			if maxLength, err := strconv.Atoi(field.Tag.Get(maxLengthTag)); err == nil && maxLength > 0 {
+				field.MaxLength = maxLength
+			}
+
+			switch fieldType := fieldVar.Type().Underlying().(type) {
+			case *types.Basic:
+				if fieldType.Kind() == types.String {
+					field.Kind = StringField
+				}
+			case *types.Signature:
+				field.Kind = FuncField
+				field.Signature = fieldType
+			}
+		}
+
+		result.Fields = append(result.Fields, field)
+	}
+
+	return result
+}
+
+// embeddedStruct returns the named struct type an embedded field points to.
+func embeddedStruct(fieldType types.Type) *types.Named {
+	pointer, ok := fieldType.(*types.Pointer)
+	if !ok {
+		return nil
+	}
+
+	return namedStruct(pointer.Elem())
+}
+
+// namedStruct returns a type if it is a named struct type.
+func namedStruct(t types.Type) *types.Named {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil
+	}
+
+	return named
+}
+
+// Find returns the message structs of a package. A struct is considered a
+// message struct if a pointer to it is passed to MessageFactory.Init or if
+// its declaration is marked with MessagesMarker. Default tags alone do not
+// make message structs, since other libraries use them as well, e.g. for
+// the default values of configuration.
+func Find(pkg *types.Package, info *types.Info, files []*ast.File) []*types.Named {
+	var found []*types.Named
+	seen := map[*types.Named]bool{}
+
+	add := func(named *types.Named) {
+		if named != nil && !seen[named] {
+			seen[named] = true
+			found = append(found, named)
+		}
+	}
+
+	for _, file := range files {
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.GenDecl:
+				for _, spec := range node.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+
+					// The comment of a type declared alone belongs to its declaration.
+					doc := typeSpec.Doc
+					if !node.Lparen.IsValid() {
+						doc = node.Doc
+					}
+
+					if object, ok := info.Defs[typeSpec.Name].(*types.TypeName); ok && hasMarker(doc) {
+						add(namedStruct(object.Type()))
+					}
+				}
+			case *ast.CallExpr:
+				if isFactoryInit(info, node) && len(node.Args) == 1 {
+					add(embeddedStruct(info.TypeOf(node.Args[0])))
+				}
+			}
+			return true
+		})
+	}
+
+	return found
+}
+
+// hasMarker reports whether a comment contains MessagesMarker on a line of
+// its own.
+func hasMarker(doc *ast.CommentGroup) bool {
+	if doc == nil {
+		return false
+	}
+
+	for _, comment := range doc.List {
+		if comment.Text == MessagesMarker {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isFactoryInit reports whether a call expression invokes MessageFactory.Init.
+func isFactoryInit(info *types.Info, call *ast.CallExpr) bool {
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != factoryInitMethod {
+		return false
+	}
+
+	method, ok := info.Uses[selector.Sel].(*types.Func)
+	if !ok {
+		return false
+	}
+
+	receiver := method.Type().(*types.Signature).Recv()
+	if receiver == nil {
+		return false
+	}
+
+	receiverType := receiver.Type()
+	if pointer, ok := receiverType.(*types.Pointer); ok {
+		receiverType = pointer.Elem()
+	}
+
+	named, ok := receiverType.(*types.Named)
+	if !ok {
+		return false
+	}
+
+	object := named.Obj()
+
+	return object.Name() == factoryType &&
+		object.Pkg() != nil &&
+		object.Pkg().Path() == factoryPackage
+}
@@ -0,0 +1,223 @@
+package scan_test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	. "github.com/sgatev/g11n/internal/scan"
+)
+
+const factorySource = `
+package g11n
+
+type MessageFactory struct{}
+
+func (mf *MessageFactory) Init(structPtr interface{}) interface{} {
+	return structPtr
+}
+`
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
+
+func typeCheck(t *testing.T, path, source string, imp types.Importer) (*types.Package, *types.Info, []*ast.File) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path+".go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+		Uses:  map[*ast.Ident]types.Object{},
+	}
+	config := types.Config{Importer: imp}
+	pkg, err := config.Check(path, fset, []*ast.File{file}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pkg, info, []*ast.File{file}
+}
+
+func typeCheckWithFactory(t *testing.T, source string) (*types.Package, *types.Info, []*ast.File) {
+	factory, _, _ := typeCheck(t, "github.com/sgatev/g11n", factorySource, importer.Default())
+
+	return typeCheck(t, "example", source, importerFunc(func(path string) (*types.Package, error) {
+		if path == factory.Path() {
+			return factory, nil
+		}
+		return importer.Default().Import(path)
+	}))
+}
+
+func lookupNamed(t *testing.T, pkg *types.Package, name string) *types.Named {
+	object := pkg.Scope().Lookup(name)
+	if object == nil {
+		t.Fatalf("Type %v is not declared.", name)
+	}
+
+	return object.Type().(*types.Named)
+}
+
+func names(types []*types.Named) []string {
+	var result []string
+	for _, named := range types {
+		result = append(result, named.Obj().Name())
+	}
+	return result
+}
+
+func TestLoadMessages(t *testing.T) {
+	pkg, _, _ := typeCheckWithFactory(t, `
+package example
+
+type SafeHTML string
+
+type N struct {
+	Embedded func() string `+"`default:\"embedded\"`"+`
+}
+
+type M struct {
+	*N
+
+	Title    string                   `+"`default:\"Title\"`"+`
+	Safe     SafeHTML                 `+"`default:\"<b>Safe</b>\"`"+`
+	Hello    func(string) string      `+"`default:\"Hi %v!\"`"+`
+	Count    int
+}
+`)
+
+	messages := Load(lookupNamed(t, pkg, "M")).Messages()
+
+	var actual []string
+	for _, message := range messages {
+		actual = append(actual, message.Key+"="+message.Default)
+	}
+
+	expected := []string{
+		"N.Embedded=embedded",
+		"M.Title=Title",
+		"M.Safe=<b>Safe</b>",
+		"M.Hello=Hi %v!",
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Messages are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}
+
+func TestLoadAnnotations(t *testing.T) {
+	pkg, _, _ := typeCheckWithFactory(t, `
+package example
+
+type M struct {
+	Pay   func() string `+"`default:\"Pay\" desc:\"Checkout button\" maxlen:\"20\"`"+`
+	Title string        `+"`default:\"Title\" maxlen:\"long\"`"+`
+}
+`)
+
+	var actual []string
+	for _, message := range Load(lookupNamed(t, pkg, "M")).Messages() {
+		actual = append(actual, fmt.Sprintf("%v=%q/%v", message.Key, message.Description, message.MaxLength))
+	}
+
+	expected := []string{
+		`M.Pay="Checkout button"/20`,
+		`M.Title=""/0`,
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Annotations are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}
+
+func TestLoadFieldKinds(t *testing.T) {
+	pkg, _, _ := typeCheckWithFactory(t, `
+package example
+
+type N struct{}
+
+type M struct {
+	N
+
+	Title string
+	Hello func() string
+	Count int
+}
+`)
+
+	var actual []FieldKind
+	for _, field := range Load(lookupNamed(t, pkg, "M")).Fields {
+		actual = append(actual, field.Kind)
+	}
+
+	expected := []FieldKind{InvalidField, StringField, FuncField, InvalidField}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Field kinds are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}
+
+func TestFind(t *testing.T) {
+	pkg, info, files := typeCheckWithFactory(t, `
+package example
+
+import "github.com/sgatev/g11n"
+
+//g11n:messages
+type Marked struct {
+	Hello func() string `+"`default:\"Hi!\"`"+`
+}
+
+type Initialized struct {
+	Hello func() string
+}
+
+type Config struct {
+	Port int `+"`default:\"8080\"`"+`
+}
+
+type (
+	// Grouped is declared in a group.
+	//g11n:messages
+	Grouped struct {
+		Hello func() string
+	}
+
+	Unrelated struct {
+		Hello func() string
+	}
+)
+
+func init() {
+	var m Initialized
+	new(g11n.MessageFactory).Init(&m)
+}
+`)
+
+	actual := names(Find(pkg, info, files))
+	expected := []string{"Marked", "Grouped", "Initialized"}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Message structs are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}
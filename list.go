@@ -0,0 +1,162 @@
+package g11n
+
+import (
+	"reflect"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// ListType selects how the items of a list are joined.
+type ListType int
+
+// List types.
+const (
+	// AndList joins the items as a conjunction, e.g. a, b, and c.
+	AndList ListType = iota
+
+	// OrList joins the items as a disjunction, e.g. a, b, or c.
+	OrList
+
+	listTypesCount
+)
+
+// List is a message parameter that formats the items of a slice or an array
+// as a list in the active locale, e.g. a, b, and c in English and a, b et c
+// in French. The items are formatted as message parameters themselves.
+type List struct {
+	Items interface{}
+	Type  ListType
+}
+
+// listPatterns holds the CLDR list patterns of a language.
+type listPatterns struct {
+	start, middle, end, two string
+}
+
+// rootListPatterns are the list patterns of the CLDR root locale, which are
+// used for languages without list patterns of their own.
+var rootListPatterns = [listTypesCount]listPatterns{
+	AndList: {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+	OrList:  {"{0}, {1}", "{0}, {1}", "{0}, {1}", "{0}, {1}"},
+}
+
+// lists maps language bases to their CLDR list patterns by list type.
+var lists = map[string][listTypesCount]listPatterns{
+	"bg": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} и {1}", "{0} и {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} или {1}", "{0} или {1}"},
+	},
+	"cs": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} a {1}", "{0} a {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} nebo {1}", "{0} nebo {1}"},
+	},
+	"de": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} und {1}", "{0} und {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} oder {1}", "{0} oder {1}"},
+	},
+	"en": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0}, and {1}", "{0} and {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0}, or {1}", "{0} or {1}"},
+	},
+	"es": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} y {1}", "{0} y {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} o {1}", "{0} o {1}"},
+	},
+	"fr": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} et {1}", "{0} et {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} ou {1}", "{0} ou {1}"},
+	},
+	"it": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} e {1}", "{0} e {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} o {1}", "{0} o {1}"},
+	},
+	"ja": {
+		AndList: {"{0}、{1}", "{0}、{1}", "{0}、{1}", "{0}、{1}"},
+		OrList:  {"{0}、{1}", "{0}、{1}", "{0}、または{1}", "{0}または{1}"},
+	},
+	"ko": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} 및 {1}", "{0} 및 {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} 또는 {1}", "{0} 또는 {1}"},
+	},
+	"nl": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} en {1}", "{0} en {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} of {1}", "{0} of {1}"},
+	},
+	"pl": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} i {1}", "{0} i {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} lub {1}", "{0} lub {1}"},
+	},
+	"pt": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} e {1}", "{0} e {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} ou {1}", "{0} ou {1}"},
+	},
+	"ru": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} и {1}", "{0} и {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} или {1}", "{0} или {1}"},
+	},
+	"sv": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} och {1}", "{0} och {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} eller {1}", "{0} eller {1}"},
+	},
+	"tr": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} ve {1}", "{0} ve {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} veya {1}", "{0} veya {1}"},
+	},
+	"uk": {
+		AndList: {"{0}, {1}", "{0}, {1}", "{0} і {1}", "{0} і {1}"},
+		OrList:  {"{0}, {1}", "{0}, {1}", "{0} або {1}", "{0} або {1}"},
+	},
+	"zh": {
+		AndList: {"{0}、{1}", "{0}、{1}", "{0}和{1}", "{0}和{1}"},
+		OrList:  {"{0}、{1}", "{0}、{1}", "{0}或{1}", "{0}或{1}"},
+	},
+}
+
+// formatList formats the items of a list in a locale.
+func formatList(tag language.Tag, list List) string {
+	items := reflect.ValueOf(list.Items)
+	if items.Kind() != reflect.Slice && items.Kind() != reflect.Array {
+		return ""
+	}
+
+	printer := newPrinter(tag)
+
+	formatted := make([]string, items.Len())
+	for i := range formatted {
+		param, _ := formatParam(items.Index(i), tag)
+		formatted[i] = sprintf(printer, "%v", param)
+	}
+
+	base, _ := tag.Base()
+	patterns, ok := lists[base.String()]
+	if !ok {
+		patterns = rootListPatterns
+	}
+
+	return joinList(patterns[list.Type], formatted)
+}
+
+// joinList joins formatted items according to list patterns.
+func joinList(patterns listPatterns, items []string) string {
+	switch len(items) {
+	case 0:
+		return ""
+	case 1:
+		return items[0]
+	case 2:
+		return joinPair(patterns.two, items[0], items[1])
+	}
+
+	result := joinPair(patterns.end, items[len(items)-2], items[len(items)-1])
+	for i := len(items) - 3; i > 0; i-- {
+		result = joinPair(patterns.middle, items[i], result)
+	}
+
+	return joinPair(patterns.start, items[0], result)
+}
+
+// joinPair substitutes two items in a list pattern.
+func joinPair(listPattern, first, second string) string {
+	return strings.NewReplacer("{0}", first, "{1}", second).Replace(listPattern)
+}
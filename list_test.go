@@ -0,0 +1,80 @@
+package g11n_test
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+type ListMessages struct {
+	Friends func(...string) string                  `default:"%v, %v and %v"`
+	Owner   func(string, ...interface{}) string     `default:"%v owns %v cats and %v dogs"`
+	Guests  func(context.Context, ...string) string `default:"%[2]v before %[1]v"`
+	Invited func(List) string                       `default:"Invited: %v"`
+}
+
+// klingon is a language without CLDR data.
+var klingon = language.MustParse("tlh")
+
+func TestVariadicMessage(t *testing.T) {
+	m := New().Init(&ListMessages{}).(*ListMessages)
+
+	testMessage(t, m.Friends("Ana", "Bob", "Eve"), "Ana, Bob and Eve")
+	testMessage(t, m.Owner("Ana", 2, CustomFormat{func() string { return "no" }}), "Ana owns 2 cats and no dogs")
+	testMessage(t, m.Guests(context.Background(), "Ana", "Bob"), "Bob before Ana")
+}
+
+func TestVariadicMessageSlice(t *testing.T) {
+	m := New().Init(&ListMessages{}).(*ListMessages)
+
+	friends := []string{"Ana", "Bob", "Eve"}
+	testMessage(t, m.Friends(friends...), "Ana, Bob and Eve")
+}
+
+func TestListParam(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`{}`))
+	factory.SetLocale(language.French, "json", TempFile(`{}`))
+	factory.SetLocale(language.Japanese, "json", TempFile(`{}`))
+	factory.SetLocale(klingon, "json", TempFile(`{}`))
+
+	m := factory.Init(&ListMessages{}).(*ListMessages)
+
+	names := []string{"Ana", "Bob", "Eve", "Ivan"}
+
+	testMessage(t, m.Invited(List{Items: names}), "Invited: Ana, Bob, Eve, and Ivan")
+	testMessage(t, m.Invited(List{Items: names[:3], Type: OrList}), "Invited: Ana, Bob, or Eve")
+	testMessage(t, m.Invited(List{Items: names[:2]}), "Invited: Ana and Bob")
+	testMessage(t, m.Invited(List{Items: names[:1]}), "Invited: Ana")
+	testMessage(t, m.Invited(List{Items: []string{}}), "Invited: ")
+
+	factory.LoadLocale(language.French)
+	testMessage(t, m.Invited(List{Items: names[:3]}), "Invited: Ana, Bob et Eve")
+
+	factory.LoadLocale(language.Bulgarian)
+	testMessage(t, m.Invited(List{Items: names[:3], Type: OrList}), "Invited: Ana, Bob или Eve")
+
+	factory.LoadLocale(language.Japanese)
+	testMessage(t, m.Invited(List{Items: names[:3]}), "Invited: Ana、Bob、Eve")
+	testMessage(t, m.Invited(List{Items: names[:3], Type: OrList}), "Invited: Ana、Bob、またはEve")
+
+	// Klingon has no CLDR locale, so it falls back to the root patterns.
+	factory.LoadLocale(klingon)
+	testMessage(t, m.Invited(List{Items: names[:3]}), "Invited: Ana, Bob, Eve")
+}
+
+func TestListParamFormatsItems(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.German, "json", TempFile(`{}`))
+	factory.LoadLocale(language.German)
+
+	m := factory.Init(&ListMessages{}).(*ListMessages)
+
+	testMessage(t,
+		m.Invited(List{Items: [...]interface{}{1234, CustomFormat{func() string { return "alle" }}}}),
+		"Invited: 1.234 und alle")
+}
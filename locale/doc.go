@@ -20,4 +20,13 @@
 // III. Built-in locale loaders
 //
 // g11n comes with two built-in locale loaders - "json" and "yaml".
+//
+//
+// IV. Locale writers
+//
+// Tools that create locale files use a Writer registered for the same format name using RegisterWriter.
+//
+//	writer, ok := GetWriter("json") (Writer, bool)
+//
+// The built-in writers store the notes of an entry as comments in "yaml" and as a sibling key prefixed with "@" in "json". Such keys are skipped by the "json" loader. Both built-in loaders read the notes back through the NotesLoader interface.
 package locale
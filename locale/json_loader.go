@@ -3,6 +3,7 @@ package locale
 import (
 	"encoding/json"
 	"io/ioutil"
+	"strings"
 )
 
 type jsonLoader struct{}
@@ -12,9 +13,29 @@ func (jl *jsonLoader) Load(fileName string) map[string]string {
 	if data, err := ioutil.ReadFile(fileName); err == nil {
 		json.Unmarshal(data, &result)
 	}
+	for key := range result {
+		if strings.HasPrefix(key, jsonNotePrefix) {
+			delete(result, key)
+		}
+	}
 	return result
 }
 
+func (jl *jsonLoader) LoadNotes(fileName string) map[string][]string {
+	values := map[string]string{}
+	if data, err := ioutil.ReadFile(fileName); err == nil {
+		json.Unmarshal(data, &values)
+	}
+
+	notes := map[string][]string{}
+	for key, value := range values {
+		if strings.HasPrefix(key, jsonNotePrefix) {
+			notes[strings.TrimPrefix(key, jsonNotePrefix)] = strings.Split(value, jsonNoteSeparator)
+		}
+	}
+	return notes
+}
+
 func init() {
 	RegisterLoader("json", &jsonLoader{})
 }
@@ -50,3 +50,22 @@ func TestLoadJsonWithDuplicateKeys(t *testing.T) {
 		"M.MyLittleSomething": "Second",
 	})
 }
+
+func TestLoadJsonNotes(t *testing.T) {
+	filePath := TempFile(`
+	{
+	  "@M.MyLittleSomething": "Pet; new",
+	  "M.MyLittleSomething": "Котка",
+	  "M.Other": "Друго"
+	}
+`)
+
+	loader, _ := GetLoader("json")
+
+	expected := map[string][]string{
+		"M.MyLittleSomething": {"Pet", "new"},
+	}
+	if actual := loader.(NotesLoader).LoadNotes(filePath); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected notes %v, got %v.", expected, actual)
+	}
+}
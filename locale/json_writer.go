@@ -0,0 +1,58 @@
+package locale
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"strings"
+)
+
+// jsonNotePrefix prefixes the keys holding the notes of a message, as JSON
+// has no comments.
+const jsonNotePrefix = "@"
+
+// jsonNoteSeparator separates the notes of a message.
+const jsonNoteSeparator = "; "
+
+type jsonWriter struct{}
+
+func (jw *jsonWriter) Write(fileName string, entries []Entry) error {
+	var buf bytes.Buffer
+
+	writePair := func(key, value string, last bool) {
+		buf.WriteString("  ")
+		buf.Write(jsonString(key))
+		buf.WriteString(": ")
+		buf.Write(jsonString(value))
+		if !last {
+			buf.WriteString(",")
+		}
+		buf.WriteString("\n")
+	}
+
+	buf.WriteString("{\n")
+	for i, entry := range entries {
+		if len(entry.Notes) > 0 {
+			writePair(jsonNotePrefix+entry.Key, strings.Join(entry.Notes, jsonNoteSeparator), false)
+		}
+		writePair(entry.Key, entry.Message, i == len(entries)-1)
+	}
+	buf.WriteString("}\n")
+
+	return ioutil.WriteFile(fileName, buf.Bytes(), 0644)
+}
+
+// jsonString encodes a string as JSON without escaping HTML characters.
+func jsonString(value string) []byte {
+	var buf bytes.Buffer
+
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.Encode(value)
+
+	return bytes.TrimRight(buf.Bytes(), "\n")
+}
+
+func init() {
+	RegisterWriter("json", &jsonWriter{})
+}
@@ -0,0 +1,49 @@
+package locale_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	. "github.com/sgatev/g11n/locale"
+	. "github.com/sgatev/g11n/test"
+)
+
+func testWriteJson(t *testing.T, entries []Entry, expected string) {
+	filePath := TempFile("")
+
+	writer, _ := GetWriter("json")
+	if err := writer.Write(filePath, entries); err != nil {
+		t.Fatal(err)
+	}
+
+	if actual, _ := ioutil.ReadFile(filePath); string(actual) != expected {
+		t.Errorf("Locale file is not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, string(actual))
+	}
+}
+
+func TestWriteJson(t *testing.T) {
+	testWriteJson(t, []Entry{
+		{Key: "M.MyLittleSomething", Message: "<b>Котка</b>"},
+		{Key: "M.Other", Message: "Куче", Notes: []string{"new"}},
+	}, `{
+  "M.MyLittleSomething": "<b>Котка</b>",
+  "@M.Other": "new",
+  "M.Other": "Куче"
+}
+`)
+}
+
+func TestWriteJsonRoundTrip(t *testing.T) {
+	filePath := TempFile("")
+
+	writer, _ := GetWriter("json")
+	writer.Write(filePath, []Entry{
+		{Key: "M.MyLittleSomething", Message: "Котка", Notes: []string{"obsolete"}},
+	})
+
+	testLoadJson(t, filePath, map[string]string{
+		"M.MyLittleSomething": "Котка",
+	})
+}
@@ -7,6 +7,14 @@ type Loader interface {
 	Load(fileName string) map[string]string
 }
 
+// NotesLoader is implemented by loaders that read the notes written by the
+// writer of the same format back.
+type NotesLoader interface {
+
+	// LoadNotes loads the notes of the messages of a locale file by key.
+	LoadNotes(fileName string) map[string][]string
+}
+
 var loaders = map[string]Loader{}
 
 // GetLoader returns the locale loader for a specific format.
@@ -0,0 +1,31 @@
+package locale
+
+// Entry represents a single message written to a locale file.
+type Entry struct {
+	Key     string
+	Message string
+
+	// Notes are remarks for translators, written as comments or notes
+	// depending on the file format.
+	Notes []string
+}
+
+// Writer represents a locale writer for a specific file format.
+type Writer interface {
+
+	// Write writes the entries of a locale to the file in their order.
+	Write(fileName string, entries []Entry) error
+}
+
+var writers = map[string]Writer{}
+
+// GetWriter returns the locale writer for a specific format.
+func GetWriter(format string) (Writer, bool) {
+	writer, ok := writers[format]
+	return writer, ok
+}
+
+// RegisterWriter registers a locale writer for specific format.
+func RegisterWriter(format string, writer Writer) {
+	writers[format] = writer
+}
@@ -1,7 +1,9 @@
 package locale
 
 import (
+	"fmt"
 	"io/ioutil"
+	"strings"
 
 	"gopkg.in/yaml.v2"
 )
@@ -16,6 +18,35 @@ func (yl *yamlLoader) Load(fileName string) map[string]string {
 	return result
 }
 
+func (yl *yamlLoader) LoadNotes(fileName string) map[string][]string {
+	notes := map[string][]string{}
+
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return notes
+	}
+
+	var comments []string
+	for _, line := range strings.Split(string(data), "\n") {
+		switch {
+		case strings.HasPrefix(line, yamlCommentPrefix):
+			comments = append(comments, strings.TrimPrefix(line, yamlCommentPrefix))
+			continue
+		case line == "" || strings.HasPrefix(line, " "):
+			// Continuation lines of multiline messages.
+			continue
+		}
+
+		var entry yaml.MapSlice
+		if yaml.Unmarshal([]byte(line), &entry) == nil && len(entry) > 0 && len(comments) > 0 {
+			notes[fmt.Sprint(entry[0].Key)] = comments
+		}
+		comments = nil
+	}
+
+	return notes
+}
+
 func init() {
 	RegisterLoader("yaml", &yamlLoader{})
 }
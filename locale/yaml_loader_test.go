@@ -44,3 +44,20 @@ M.MyLittleSomething: Second
 		"M.MyLittleSomething": "Second",
 	})
 }
+
+func TestLoadYamlNotes(t *testing.T) {
+	filePath := TempFile(`# Pet
+# new
+M.MyLittleSomething: Котка
+M.Other: Друго
+`)
+
+	loader, _ := GetLoader("yaml")
+
+	expected := map[string][]string{
+		"M.MyLittleSomething": {"Pet", "new"},
+	}
+	if actual := loader.(NotesLoader).LoadNotes(filePath); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected notes %v, got %v.", expected, actual)
+	}
+}
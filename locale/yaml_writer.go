@@ -0,0 +1,35 @@
+package locale
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+// yamlCommentPrefix starts the comments holding the notes of a message.
+const yamlCommentPrefix = "# "
+
+type yamlWriter struct{}
+
+func (yw *yamlWriter) Write(fileName string, entries []Entry) error {
+	var buf bytes.Buffer
+
+	for _, entry := range entries {
+		for _, note := range entry.Notes {
+			buf.WriteString(yamlCommentPrefix + note + "\n")
+		}
+
+		data, err := yaml.Marshal(yaml.MapSlice{{Key: entry.Key, Value: entry.Message}})
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+	}
+
+	return ioutil.WriteFile(fileName, buf.Bytes(), 0644)
+}
+
+func init() {
+	RegisterWriter("yaml", &yamlWriter{})
+}
@@ -0,0 +1,47 @@
+package locale_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	. "github.com/sgatev/g11n/locale"
+	. "github.com/sgatev/g11n/test"
+)
+
+func testWriteYaml(t *testing.T, entries []Entry, expected string) {
+	filePath := TempFile("")
+
+	writer, _ := GetWriter("yaml")
+	if err := writer.Write(filePath, entries); err != nil {
+		t.Fatal(err)
+	}
+
+	if actual, _ := ioutil.ReadFile(filePath); string(actual) != expected {
+		t.Errorf("Locale file is not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, string(actual))
+	}
+}
+
+func TestWriteYaml(t *testing.T) {
+	testWriteYaml(t, []Entry{
+		{Key: "M.MyLittleSomething", Message: "Котка: %v"},
+		{Key: "M.Other", Message: "Куче", Notes: []string{"new"}},
+	}, `M.MyLittleSomething: 'Котка: %v'
+# new
+M.Other: Куче
+`)
+}
+
+func TestWriteYamlRoundTrip(t *testing.T) {
+	filePath := TempFile("")
+
+	writer, _ := GetWriter("yaml")
+	writer.Write(filePath, []Entry{
+		{Key: "M.MyLittleSomething", Message: "Котка: %v", Notes: []string{"obsolete"}},
+	})
+
+	testLoadYaml(t, filePath, map[string]string{
+		"M.MyLittleSomething": "Котка: %v",
+	})
+}
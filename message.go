@@ -0,0 +1,152 @@
+package g11n
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strconv"
+
+	"golang.org/x/text/language"
+)
+
+// Message is a message whose localization is deferred until it is rendered,
+// e.g. a message of an email that is queued before its recipient is known.
+// It is returned by message funcs whose result type is Message.
+//
+// A Message could be serialized to JSON and bound to a message factory again
+// with Bind. Numbers, strings and booleans are restored to the types of the
+// parameters of the message func. Other arguments are restored as JSON values,
+// so they should be types that survive the round trip.
+type Message struct {
+
+	// Key is the key of the message.
+	Key string `json:"key"`
+
+	// Args are the arguments the message func was called with.
+	Args []interface{} `json:"args,omitempty"`
+
+	factory *MessageFactory
+}
+
+// messageType is the reflected type of Message.
+var messageType = reflect.TypeOf(Message{})
+
+// UnmarshalJSON implements json.Unmarshaler. Numeric arguments are decoded
+// as json.Number, so that Bind restores them without loss of precision.
+func (m *Message) UnmarshalJSON(data []byte) error {
+	type plainMessage Message
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode((*plainMessage)(m))
+}
+
+// Bind returns a copy of a message that is rendered by a message factory,
+// e.g. a message that was deserialized from JSON. The arguments are converted
+// to the types of the parameters of the message func.
+func (mf *MessageFactory) Bind(message Message) Message {
+	message.factory = mf
+
+	var params []reflect.Type
+	variadic := false
+	if descriptor, ok := mf.message(message.Key); ok {
+		params = descriptor.Params
+		variadic = descriptor.Type.Kind() == reflect.Func && descriptor.Type.IsVariadic()
+	}
+
+	args := make([]interface{}, len(message.Args))
+	for i, arg := range message.Args {
+		var paramType reflect.Type
+		switch {
+		case variadic && i >= len(params)-1:
+			paramType = params[len(params)-1].Elem()
+		case i < len(params):
+			paramType = params[i]
+		}
+
+		args[i] = restoreArg(arg, paramType)
+	}
+	message.Args = args
+
+	return message
+}
+
+// restoreArg converts a deserialized argument to the type of its parameter,
+// if any. Numbers of unknown parameters are restored as int64 or float64.
+func restoreArg(arg interface{}, paramType reflect.Type) interface{} {
+	number, isNumber := arg.(json.Number)
+	if !isNumber {
+		value := reflect.ValueOf(arg)
+		if paramType != nil && value.IsValid() && value.Kind() == paramType.Kind() &&
+			value.Type().ConvertibleTo(paramType) {
+			return value.Convert(paramType).Interface()
+		}
+
+		return arg
+	}
+
+	kind := reflect.Invalid
+	if paramType != nil {
+		kind = paramType.Kind()
+	}
+
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if n, err := strconv.ParseInt(number.String(), 10, 64); err == nil {
+			return reflect.ValueOf(n).Convert(paramType).Interface()
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if n, err := strconv.ParseUint(number.String(), 10, 64); err == nil {
+			return reflect.ValueOf(n).Convert(paramType).Interface()
+		}
+	case reflect.Float32, reflect.Float64:
+		if f, err := number.Float64(); err == nil {
+			return reflect.ValueOf(f).Convert(paramType).Interface()
+		}
+	}
+
+	if n, err := number.Int64(); err == nil {
+		return n
+	}
+	if f, err := number.Float64(); err == nil {
+		return f
+	}
+
+	return number.String()
+}
+
+// String returns the message in the active locale of its message factory.
+// The key is returned if the message is not bound to a message factory.
+func (m Message) String() string {
+	if m.factory == nil {
+		return m.Key
+	}
+
+	return m.factory.translate(m.factory.activeDictionary(), m.Key, m.Args).String()
+}
+
+// In returns the message in the registered locale that best matches a locale.
+func (m Message) In(tag language.Tag) string {
+	if m.factory == nil {
+		return m.Key
+	}
+
+	dictionary := m.factory.localeDictionary(m.factory.resolveLocale(tag))
+
+	return m.factory.translate(dictionary, m.Key, m.Args).String()
+}
+
+// lazyMessage creates the Message result of a message func.
+func (mf *MessageFactory) lazyMessage(messageKey string, args []reflect.Value) reflect.Value {
+	message := Message{
+		Key:     messageKey,
+		Args:    make([]interface{}, len(args)),
+		factory: mf,
+	}
+	for i, arg := range args {
+		message.Args[i] = arg.Interface()
+	}
+
+	return reflect.ValueOf(message)
+}
@@ -0,0 +1,91 @@
+package g11n_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+type LazyMessages struct {
+	Welcome func(string) Message `default:"Welcome %v!"`
+	Goodbye func() Message       `default:"Goodbye!"`
+}
+
+func TestMessage(t *testing.T) {
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"LazyMessages.Welcome": "Добре дошъл %v!"}`),
+		language.Spanish:   TempFile(`{"LazyMessages.Welcome": "¡Bienvenido %v!"}`),
+	}, "json")
+
+	m := factory.Init(&LazyMessages{}).(*LazyMessages)
+
+	message := m.Welcome("Ivan")
+	testMessage(t, message.String(), "Welcome Ivan!")
+	testMessage(t, fmt.Sprint(message), "Welcome Ivan!")
+
+	factory.LoadLocale(language.Bulgarian)
+
+	testMessage(t, message.String(), "Добре дошъл Ivan!")
+	testMessage(t, message.In(language.Spanish), "¡Bienvenido Ivan!")
+	testMessage(t, m.Goodbye().In(language.Spanish), "Goodbye!")
+}
+
+func TestMessageJSON(t *testing.T) {
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.Bulgarian: TempFile(`{"LazyMessages.Welcome": "Добре дошъл %v!"}`),
+		language.Spanish:   TempFile(`{"LazyMessages.Welcome": "¡Bienvenido %v!"}`),
+	}, "json")
+
+	m := factory.Init(&LazyMessages{}).(*LazyMessages)
+
+	data, err := json.Marshal(m.Welcome("Ivan"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	testMessage(t, string(data), `{"key":"LazyMessages.Welcome","args":["Ivan"]}`)
+
+	var message Message
+	if err := json.Unmarshal(data, &message); err != nil {
+		t.Fatal(err)
+	}
+	testMessage(t, message.String(), "LazyMessages.Welcome")
+
+	message = factory.Bind(message)
+	testMessage(t, message.In(language.Bulgarian), "Добре дошъл Ivan!")
+	testMessage(t, message.String(), "Welcome Ivan!")
+}
+
+func TestMessageJSONParams(t *testing.T) {
+	type M struct {
+		Cats  func(int) Message              `default:"You have %d cats"`
+		Done  func(Percent) Message          `default:"Done: %v"`
+		Sizes func(string, ...uint8) Message `default:"%v: %d and %d"`
+	}
+
+	factory := New()
+	m := factory.Init(&M{}).(*M)
+
+	messages := []Message{m.Cats(3), m.Done(0.25), m.Sizes("Shoes", 42, 43)}
+	expected := []string{"You have 3 cats", "Done: 25%", "Shoes: 42 and 43"}
+
+	for i, message := range messages {
+		data, err := json.Marshal(message)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var unmarshaled Message
+		if err := json.Unmarshal(data, &unmarshaled); err != nil {
+			t.Fatal(err)
+		}
+
+		testMessage(t, factory.Bind(unmarshaled).String(), expected[i])
+	}
+}
@@ -0,0 +1,16 @@
+package g11n
+
+import (
+	"golang.org/x/text/currency"
+)
+
+// Currency is a message parameter that formats an amount of money with the
+// currency symbol, grouping and decimal separators of the active locale.
+type Currency struct {
+	Amount float64
+	Code   currency.Unit
+}
+
+// Percent is a message parameter that formats a ratio as a percentage in
+// the active locale, e.g. Percent(0.25) as 25%.
+type Percent float64
@@ -0,0 +1,87 @@
+package g11n_test
+
+import (
+	"testing"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+func TestNumberFormatting(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(int, float64) string `default:"%v and %.2f"`
+	}
+
+	factory := New()
+	factory.SetLocales(map[language.Tag]string{
+		language.English: TempFile(`{}`),
+		language.German:  TempFile(`{}`),
+	}, "json")
+
+	m := factory.Init(&M{}).(*M)
+
+	factory.LoadLocale(language.English)
+	testMessage(t,
+		m.MyLittleSomething(1234567, 1234.5),
+		"1,234,567 and 1,234.50")
+
+	factory.LoadLocale(language.German)
+	testMessage(t,
+		m.MyLittleSomething(1234567, 1234.5),
+		"1.234.567 and 1.234,50")
+}
+
+func TestNumberFormattingWithoutLocale(t *testing.T) {
+	type M struct {
+		Since func(int) string            `default:"Since %v"`
+		Total func(float64, []int) string `default:"%.2f of %v"`
+		Count func(List) string           `default:"%v"`
+		Price func(Currency) string       `default:"%v"`
+	}
+
+	m := New().Init(&M{}).(*M)
+
+	testMessage(t, m.Since(2015), "Since 2015")
+	testMessage(t, m.Total(1234.5, []int{1000, 2000}), "1234.50 of [1000 2000]")
+	testMessage(t, m.Count(List{Items: []int{1000, 2000}}), "1000 and 2000")
+	testMessage(t, m.Price(Currency{Amount: 1234.5, Code: currency.EUR}), "€ 1,234.50")
+}
+
+func TestCurrencyFormatting(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(Currency) string `default:"Total: %v"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.German, "json", TempFile(`{}`))
+	factory.LoadLocale(language.German)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething(Currency{Amount: 1234.5, Code: currency.EUR}),
+		"Total: € 1.234,50")
+}
+
+func TestPercentFormatting(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(Percent) string `default:"Done: %v"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.German, "json", TempFile(`{}`))
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething(0.25),
+		"Done: 25%")
+
+	factory.LoadLocale(language.German)
+	testMessage(t,
+		m.MyLittleSomething(0.25),
+		"Done: 25\u00a0%")
+}
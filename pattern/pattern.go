@@ -0,0 +1,188 @@
+// Package pattern inspects the fmt patterns of g11n messages.
+package pattern
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Verb represents a formatting verb of a message pattern, e.g. %v or %[2]d.
+type Verb struct {
+
+	// Verb is the verb character, e.g. 'v'.
+	Verb rune
+
+	// Arg is the zero-based index of the formatted argument.
+	Arg int
+
+	// Stars are the zero-based indexes of the arguments of star width and
+	// precision, e.g. %*d.
+	Stars []int
+
+	// Start and End are the byte offsets of the verb in the pattern.
+	Start, End int
+}
+
+// String returns the verb as written in the pattern.
+func (v Verb) String() string {
+	return "%" + string(v.Verb)
+}
+
+// Parse returns the formatting verbs of a pattern in order of appearance,
+// following the argument numbering rules of package fmt. Star width and
+// precision arguments are recorded in the Stars of their verb.
+func Parse(pattern string) []Verb {
+	var verbs []Verb
+
+	arg := 0
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '%' {
+			continue
+		}
+
+		start := i
+		var stars []int
+		i++
+
+		// Flags.
+		for i < len(pattern) && strings.IndexByte("+-# 0", pattern[i]) >= 0 {
+			i++
+		}
+
+		// Argument index, width and precision.
+		for part := 0; part < 2 && i < len(pattern); part++ {
+			if index, end, ok := argIndex(pattern, i); ok {
+				arg = index
+				i = end
+			}
+
+			if i < len(pattern) && pattern[i] == '*' {
+				stars = append(stars, arg)
+				arg++
+				i++
+			} else {
+				for i < len(pattern) && pattern[i] >= '0' && pattern[i] <= '9' {
+					i++
+				}
+			}
+
+			if part == 0 && i < len(pattern) && pattern[i] == '.' {
+				i++
+			} else {
+				break
+			}
+		}
+
+		if index, end, ok := argIndex(pattern, i); ok {
+			arg = index
+			i = end
+		}
+
+		if i >= len(pattern) {
+			break
+		}
+
+		verb := rune(pattern[i])
+		if verb == '%' {
+			continue
+		}
+
+		verbs = append(verbs, Verb{
+			Verb:  verb,
+			Arg:   arg,
+			Stars: stars,
+			Start: start,
+			End:   i + 1,
+		})
+		arg++
+	}
+
+	return verbs
+}
+
+// Count returns the number of arguments a pattern needs, i.e. one more than
+// the highest argument referenced by its verbs and placeholders. The verbs
+// of the cases of select placeholders are counted separately for each case.
+// Args returns the arguments that are actually referenced.
+func Count(pattern string) int {
+	count := countPlaceholders(pattern)
+
+	for _, variant := range Variants(pattern) {
+		for _, verb := range Parse(variant) {
+			if verb.Arg+1 > count {
+				count = verb.Arg + 1
+			}
+		}
+
+		if variantCount := countPlaceholders(variant); variantCount > count {
+			count = variantCount
+		}
+	}
+
+	return count
+}
+
+// Args returns the zero-based indexes of the arguments referenced by the
+// verbs and the placeholders of a pattern, including the cases of its select
+// placeholders, in increasing order.
+func Args(pattern string) []int {
+	referenced := map[int]bool{}
+
+	for _, placeholder := range ParsePlaceholders(pattern) {
+		referenced[placeholder.Arg] = true
+	}
+
+	for _, variant := range Variants(pattern) {
+		for _, verb := range Parse(variant) {
+			referenced[verb.Arg] = true
+			for _, star := range verb.Stars {
+				referenced[star] = true
+			}
+		}
+
+		for _, placeholder := range ParsePlaceholders(variant) {
+			referenced[placeholder.Arg] = true
+		}
+	}
+
+	args := make([]int, 0, len(referenced))
+	for arg := range referenced {
+		args = append(args, arg)
+	}
+	sort.Ints(args)
+
+	return args
+}
+
+// countPlaceholders returns the number of arguments referenced by the
+// placeholders of a pattern.
+func countPlaceholders(pattern string) int {
+	count := 0
+	for _, placeholder := range ParsePlaceholders(pattern) {
+		if placeholder.Arg+1 > count {
+			count = placeholder.Arg + 1
+		}
+	}
+
+	return count
+}
+
+// argIndex parses an explicit argument index such as [2] at position i.
+func argIndex(pattern string, i int) (index, end int, ok bool) {
+	if i >= len(pattern) || pattern[i] != '[' {
+		return 0, i, false
+	}
+
+	closing := strings.IndexByte(pattern[i:], ']')
+	if closing < 0 {
+		return 0, i, false
+	}
+
+	number, err := strconv.Atoi(pattern[i+1 : i+closing])
+	if err != nil || number < 1 {
+		return 0, i, false
+	}
+
+	return number - 1, i + closing + 1, true
+}
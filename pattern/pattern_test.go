@@ -0,0 +1,60 @@
+package pattern_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/sgatev/g11n/pattern"
+)
+
+func testCount(t *testing.T, pattern string, expected int) {
+	if actual := Count(pattern); actual != expected {
+		t.Errorf("Argument count of %q is not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", pattern, expected, actual)
+	}
+}
+
+func TestCount(t *testing.T) {
+	testCount(t, "No arguments.", 0)
+	testCount(t, "100%% sure", 0)
+	testCount(t, "The answer to %v is %v.", 2)
+	testCount(t, "%-5.2f and %+d", 2)
+	testCount(t, "%[2]v before %[1]v", 2)
+	testCount(t, "%[3]v", 3)
+	testCount(t, "%*d", 2)
+	testCount(t, "Trailing %", 0)
+}
+
+func testArgs(t *testing.T, pattern string, expected []int) {
+	if actual := Args(pattern); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Arguments of %q are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", pattern, expected, actual)
+	}
+}
+
+func TestArgs(t *testing.T) {
+	testArgs(t, "No arguments.", []int{})
+	testArgs(t, "The answer to %v is %v.", []int{0, 1})
+	testArgs(t, "%[2]v", []int{1})
+	testArgs(t, "%[3]v and %[1]v", []int{0, 2})
+	testArgs(t, "%*d", []int{0, 1})
+	testArgs(t, "%v on {3, date, short}", []int{0, 2})
+	testArgs(t, "{1, select, female {%[2]v} other {%[3]v}}", []int{0, 1, 2})
+}
+
+func TestParse(t *testing.T) {
+	actual := Parse("Hi %v, you are %[1]q and %5.2f%%")
+	expected := []Verb{
+		{Verb: 'v', Arg: 0, Start: 3, End: 5},
+		{Verb: 'q', Arg: 0, Start: 15, End: 20},
+		{Verb: 'f', Arg: 1, Start: 25, End: 30},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Verbs are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}
@@ -0,0 +1,114 @@
+package pattern
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Placeholder represents an argument placeholder in braces, e.g.
+// {2, date, medium}, that selects how the argument is formatted.
+type Placeholder struct {
+
+	// Arg is the zero-based index of the formatted argument.
+	Arg int
+
+	// Type is the kind of formatting, e.g. date.
+	Type string
+
+	// Style is the remaining text of the placeholder, e.g. medium.
+	Style string
+
+	// Start and End are the byte offsets of the placeholder in the pattern.
+	Start, End int
+}
+
+// ParsePlaceholders returns the placeholders of a pattern in order of
+// appearance. The argument number in a placeholder is one-based as in the
+// explicit argument indexes of package fmt. Braces that do not form a
+// placeholder are ignored.
+func ParsePlaceholders(pattern string) []Placeholder {
+	var placeholders []Placeholder
+
+	for i := 0; i < len(pattern); i++ {
+		if pattern[i] != '{' {
+			continue
+		}
+
+		if placeholder, ok := parsePlaceholder(pattern, i); ok {
+			placeholders = append(placeholders, placeholder)
+			i = placeholder.End - 1
+		}
+	}
+
+	return placeholders
+}
+
+// parsePlaceholder parses the placeholder that starts at position start.
+func parsePlaceholder(pattern string, start int) (Placeholder, bool) {
+	end := matchingBrace(pattern, start)
+	if end < 0 {
+		return Placeholder{}, false
+	}
+
+	parts := strings.SplitN(pattern[start+1:end], ",", 3)
+	if len(parts) < 2 {
+		return Placeholder{}, false
+	}
+
+	number, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || number < 1 {
+		return Placeholder{}, false
+	}
+
+	placeholderType := strings.TrimSpace(parts[1])
+	if !isIdentifier(placeholderType) {
+		return Placeholder{}, false
+	}
+
+	placeholder := Placeholder{
+		Arg:   number - 1,
+		Type:  placeholderType,
+		Start: start,
+		End:   end + 1,
+	}
+	if len(parts) == 3 {
+		placeholder.Style = strings.TrimSpace(parts[2])
+	}
+
+	return placeholder, true
+}
+
+// matchingBrace returns the position of the brace that closes the one at
+// position start, taking nested braces into account, or -1.
+func matchingBrace(pattern string, start int) int {
+	depth := 0
+	for i := start; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// isIdentifier reports whether a placeholder type is a non-empty word.
+func isIdentifier(word string) bool {
+	if word == "" {
+		return false
+	}
+
+	for _, r := range word {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+
+	return true
+}
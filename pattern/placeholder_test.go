@@ -0,0 +1,28 @@
+package pattern_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/sgatev/g11n/pattern"
+)
+
+func TestParsePlaceholders(t *testing.T) {
+	actual := ParsePlaceholders("On {2, date, medium} at {2,time} {x} {1} {3, select, a {A} other {B}}")
+	expected := []Placeholder{
+		{Arg: 1, Type: "date", Style: "medium", Start: 3, End: 20},
+		{Arg: 1, Type: "time", Start: 24, End: 32},
+		{Arg: 2, Type: "select", Style: "a {A} other {B}", Start: 41, End: 69},
+	}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Placeholders are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}
+
+func TestCountPlaceholders(t *testing.T) {
+	testCount(t, "%v was here {3, relative}", 3)
+	testCount(t, "{1, date} %v", 1)
+}
@@ -0,0 +1,104 @@
+package pattern
+
+// Select placeholder constants.
+const (
+	// SelectType is the type of select placeholders, e.g.
+	// {1, select, female {She} male {He} other {They}}.
+	SelectType = "select"
+
+	// OtherCase is the case of a select placeholder that is used when no
+	// other case matches.
+	OtherCase = "other"
+)
+
+// Case is a variant of a select placeholder.
+type Case struct {
+
+	// Name is the value that selects the case, e.g. female.
+	Name string
+
+	// Pattern is the message pattern of the case.
+	Pattern string
+}
+
+// ParseCases parses the style of a select placeholder, e.g.
+// female {She} other {They}, into its cases.
+func ParseCases(style string) ([]Case, bool) {
+	var cases []Case
+
+	for i := 0; ; {
+		for i < len(style) && style[i] == ' ' {
+			i++
+		}
+		if i == len(style) {
+			break
+		}
+
+		nameStart := i
+		for i < len(style) && style[i] != ' ' && style[i] != '{' {
+			i++
+		}
+		name := style[nameStart:i]
+
+		for i < len(style) && style[i] == ' ' {
+			i++
+		}
+		if name == "" || i == len(style) || style[i] != '{' {
+			return nil, false
+		}
+
+		end := matchingBrace(style, i)
+		if end < 0 {
+			return nil, false
+		}
+
+		cases = append(cases, Case{
+			Name:    name,
+			Pattern: style[i+1 : end],
+		})
+		i = end + 1
+	}
+
+	return cases, len(cases) > 0
+}
+
+// Select returns the pattern of the case of a select placeholder with the
+// given name, falling back to the other case.
+func Select(cases []Case, name string) string {
+	other := ""
+	for _, c := range cases {
+		if c.Name == name {
+			return c.Pattern
+		}
+		if c.Name == OtherCase {
+			other = c.Pattern
+		}
+	}
+
+	return other
+}
+
+// Variants returns every pattern that a message pattern could produce by
+// selecting one case of each of its select placeholders.
+func Variants(pattern string) []string {
+	for _, placeholder := range ParsePlaceholders(pattern) {
+		if placeholder.Type != SelectType {
+			continue
+		}
+
+		cases, ok := ParseCases(placeholder.Style)
+		if !ok {
+			continue
+		}
+
+		var variants []string
+		for _, c := range cases {
+			variant := pattern[:placeholder.Start] + c.Pattern + pattern[placeholder.End:]
+			variants = append(variants, Variants(variant)...)
+		}
+
+		return variants
+	}
+
+	return []string{pattern}
+}
@@ -0,0 +1,66 @@
+package pattern_test
+
+import (
+	"reflect"
+	"testing"
+
+	. "github.com/sgatev/g11n/pattern"
+)
+
+func TestParseCases(t *testing.T) {
+	actual, ok := ParseCases("female {She has {2, date}} male{He has %[3]v}  other {They}")
+	expected := []Case{
+		{Name: "female", Pattern: "She has {2, date}"},
+		{Name: "male", Pattern: "He has %[3]v"},
+		{Name: "other", Pattern: "They"},
+	}
+
+	if !ok || !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Cases are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}
+
+func TestParseCasesMalformed(t *testing.T) {
+	for _, style := range []string{"", "female", "female {She", "{She}", "female She"} {
+		if cases, ok := ParseCases(style); ok {
+			t.Errorf("Style %q is parsed as %v.", style, cases)
+		}
+	}
+}
+
+func TestSelect(t *testing.T) {
+	cases := []Case{
+		{Name: "female", Pattern: "She"},
+		{Name: "other", Pattern: "They"},
+	}
+
+	if actual := Select(cases, "female"); actual != "She" {
+		t.Errorf("Case female is not selected. Actual: %v", actual)
+	}
+	if actual := Select(cases, "male"); actual != "They" {
+		t.Errorf("Case other is not selected. Actual: %v", actual)
+	}
+	if actual := Select(cases[:1], "male"); actual != "" {
+		t.Errorf("Missing case is selected. Actual: %v", actual)
+	}
+}
+
+func TestVariants(t *testing.T) {
+	actual := Variants("{1, select, a {A{2, select, x {X} other {Y}}} other {B}}!")
+	expected := []string{"AX!", "AY!", "B!"}
+
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Variants are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}
+
+func TestCountSelect(t *testing.T) {
+	testCount(t, "{1, select, female {She met %[2]v} other {They met %[2]v}}", 2)
+	testCount(t, "{1, select, female {She} other {They}} left", 1)
+	testCount(t, "%v {2, select, female {she %v} other {they}}", 2)
+	testCount(t, "{1, select, female {She met %[3]v} other {They}} {2, date}", 3)
+}
@@ -0,0 +1,230 @@
+package g11n
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/sgatev/g11n/pattern"
+
+	"golang.org/x/text/language"
+)
+
+// Pseudo-localization locales.
+var (
+	// PseudoAccented is the conventional tag of a pseudo-locale with accented letters.
+	PseudoAccented = language.MustParse("en-XA")
+
+	// PseudoBidi is the conventional tag of a right-to-left pseudo-locale.
+	PseudoBidi = language.MustParse("ar-XB")
+)
+
+// Pseudo-localization marks.
+const (
+	pseudoStart      = "["
+	pseudoEnd        = "]"
+	pseudoPadding    = "~"
+	rightToLeftMark  = "\u202e"
+	popDirectionMark = "\u202c"
+)
+
+// Placeholder delimiters preserved by pseudo-localization.
+const (
+	placeholderOpen  = '{'
+	placeholderClose = '}'
+)
+
+// pseudoAccents maps ASCII letters to their accented counterparts.
+var pseudoAccents = map[rune]rune{
+	'a': 'å', 'b': 'ƀ', 'c': 'ç', 'd': 'ð', 'e': 'é', 'f': 'ƒ', 'g': 'ĝ',
+	'h': 'ĥ', 'i': 'î', 'j': 'ĵ', 'k': 'ķ', 'l': 'ļ', 'm': 'ɱ', 'n': 'ñ',
+	'o': 'ö', 'p': 'þ', 'q': 'ǫ', 'r': 'ŕ', 's': 'š', 't': 'ţ', 'u': 'û',
+	'v': 'ṽ', 'w': 'ŵ', 'x': 'ẋ', 'y': 'ý', 'z': 'ž',
+	'A': 'Å', 'B': 'Ɓ', 'C': 'Ç', 'D': 'Ð', 'E': 'É', 'F': 'Ƒ', 'G': 'Ĝ',
+	'H': 'Ĥ', 'I': 'Î', 'J': 'Ĵ', 'K': 'Ķ', 'L': 'Ļ', 'M': 'Ṁ', 'N': 'Ñ',
+	'O': 'Ö', 'P': 'Þ', 'Q': 'Ǫ', 'R': 'Ŕ', 'S': 'Š', 'T': 'Ţ', 'U': 'Û',
+	'V': 'Ṽ', 'W': 'Ŵ', 'X': 'Ẋ', 'Y': 'Ý', 'Z': 'Ž',
+}
+
+// PseudoOptions configures the transformation of the default messages in
+// a pseudo-locale.
+type PseudoOptions struct {
+
+	// Expansion is the percentage by which messages are padded to simulate
+	// longer translations.
+	Expansion int
+
+	// Bidi marks the words of messages as right-to-left text instead of
+	// accenting their letters.
+	Bidi bool
+}
+
+// SetPseudoLocale registers a pseudo-locale that requires no localization file.
+// Loading it transforms the default pattern of every message while preserving
+// its verbs, placeholders and HTML markup.
+//
+// Pseudo-locales are not listed by Locales and never matched by MatchLocale,
+// so they are only used when they are loaded by LoadLocale or carried by a
+// context from WithLocale.
+func (mf *MessageFactory) SetPseudoLocale(tag language.Tag, options PseudoOptions) {
+	mf.registerLocale(tag, localeInfo{
+		pseudo: &options,
+	})
+}
+
+// transform pseudo-localizes a message pattern.
+func (po *PseudoOptions) transform(messagePattern string) string {
+	var result strings.Builder
+
+	result.WriteString(pseudoStart)
+	letters := po.transformPattern(&result, messagePattern)
+
+	padding := (letters*po.Expansion + 99) / 100
+	result.WriteString(strings.Repeat(pseudoPadding, padding))
+	result.WriteString(pseudoEnd)
+
+	return result.String()
+}
+
+// transformPattern pseudo-localizes a message pattern without its marks and
+// returns the number of letters in it. The cases of select placeholders are
+// transformed as patterns of their own and the longest one is counted.
+func (po *PseudoOptions) transformPattern(result *strings.Builder, messagePattern string) int {
+	letters := 0
+
+	last := 0
+	for _, placeholder := range pattern.ParsePlaceholders(messagePattern) {
+		letters += po.transformVerbs(result, messagePattern[last:placeholder.Start])
+		last = placeholder.End
+
+		cases, ok := pattern.ParseCases(placeholder.Style)
+		if placeholder.Type != pattern.SelectType || !ok {
+			result.WriteString(messagePattern[placeholder.Start:placeholder.End])
+			continue
+		}
+
+		fmt.Fprintf(result, "{%d, %s,", placeholder.Arg+1, pattern.SelectType)
+		longest := 0
+		for _, c := range cases {
+			fmt.Fprintf(result, " %s {", c.Name)
+			if caseLetters := po.transformPattern(result, c.Pattern); caseLetters > longest {
+				longest = caseLetters
+			}
+			result.WriteRune(placeholderClose)
+		}
+		result.WriteRune(placeholderClose)
+		letters += longest
+	}
+	letters += po.transformVerbs(result, messagePattern[last:])
+
+	return letters
+}
+
+// transformVerbs pseudo-localizes the text around the verbs of a pattern and
+// returns the number of letters in it.
+func (po *PseudoOptions) transformVerbs(result *strings.Builder, text string) int {
+	letters := 0
+
+	last := 0
+	for _, verb := range pattern.Parse(text) {
+		letters += po.transformText(result, text[last:verb.Start])
+		result.WriteString(text[verb.Start:verb.End])
+		last = verb.End
+	}
+	letters += po.transformText(result, text[last:])
+
+	return letters
+}
+
+// transformText pseudo-localizes the text between verbs and returns the
+// number of letters in it. Other text in braces, HTML tags and character
+// references are kept as they are.
+func (po *PseudoOptions) transformText(result *strings.Builder, text string) int {
+	letters := 0
+	depth := 0
+	inWord := false
+
+	endWord := func() {
+		if inWord && po.Bidi {
+			result.WriteString(popDirectionMark)
+		}
+		inWord = false
+	}
+
+	for i := 0; i < len(text); {
+		if end := markupEnd(text, i); depth == 0 && end > 0 {
+			endWord()
+			result.WriteString(text[i:end])
+			i = end
+			continue
+		}
+
+		r, size := utf8.DecodeRuneInString(text[i:])
+		i += size
+
+		switch {
+		case r == placeholderOpen:
+			endWord()
+			depth++
+			result.WriteRune(r)
+		case r == placeholderClose && depth > 0:
+			depth--
+			result.WriteRune(r)
+		case depth > 0:
+			result.WriteRune(r)
+		case unicode.IsLetter(r):
+			letters++
+			if !inWord && po.Bidi {
+				result.WriteString(rightToLeftMark)
+			}
+			inWord = true
+
+			if accented, ok := pseudoAccents[r]; ok && !po.Bidi {
+				r = accented
+			}
+			result.WriteRune(r)
+		default:
+			endWord()
+			result.WriteRune(r)
+		}
+	}
+	endWord()
+
+	return letters
+}
+
+// markupEnd returns the end of the HTML tag, e.g. <b> or </b>, or the
+// character reference, e.g. &amp; or &#38;, that starts at position start of
+// a text, or -1.
+func markupEnd(text string, start int) int {
+	switch text[start] {
+	case '<':
+		if start+1 == len(text) {
+			return -1
+		}
+		if next := rune(text[start+1]); next != '/' && next != '!' && !unicode.IsLetter(next) {
+			return -1
+		}
+
+		if end := strings.IndexByte(text[start:], '>'); end > 0 {
+			return start + end + 1
+		}
+	case '&':
+		i := start + 1
+		if i < len(text) && text[i] == '#' {
+			i++
+		}
+
+		nameStart := i
+		for i < len(text) && (unicode.IsLetter(rune(text[i])) || unicode.IsDigit(rune(text[i]))) {
+			i++
+		}
+
+		if i > nameStart && i < len(text) && text[i] == ';' {
+			return i + 1
+		}
+	}
+
+	return -1
+}
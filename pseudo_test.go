@@ -0,0 +1,140 @@
+package g11n_test
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+func TestPseudoAccented(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(string, int) string `default:"Hi %v, %d {count}!"`
+	}
+
+	factory := New()
+	factory.SetPseudoLocale(PseudoAccented, PseudoOptions{Expansion: 50})
+	factory.LoadLocale(PseudoAccented)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething("Gopher", 42),
+		"[Ĥî Gopher, 42 {count}!~]")
+}
+
+func TestPseudoBidi(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(string) string `default:"Hi %v"`
+	}
+
+	factory := New()
+	factory.SetPseudoLocale(PseudoBidi, PseudoOptions{Bidi: true})
+	factory.LoadLocale(PseudoBidi)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething("Gopher"),
+		"[‮Hi‬ Gopher]")
+}
+
+func TestPseudoString(t *testing.T) {
+	type M struct {
+		MyLittleSomething string `default:"Cat"`
+	}
+
+	factory := New()
+	factory.SetPseudoLocale(PseudoAccented, PseudoOptions{Expansion: 100})
+
+	m := factory.Init(&M{}).(*M)
+	factory.LoadLocale(PseudoAccented)
+
+	testMessage(t,
+		m.MyLittleSomething,
+		"[Çåţ~~~]")
+}
+
+func TestPseudoKeepsPercent(t *testing.T) {
+	type M struct {
+		MyLittleSomething func() string `default:"100%% sure"`
+	}
+
+	factory := New()
+	factory.SetPseudoLocale(PseudoAccented, PseudoOptions{})
+	factory.LoadLocale(PseudoAccented)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething(),
+		"[100% šûŕé]")
+}
+
+func TestPseudoKeepsMarkup(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(string) string `default:"<b>Hi</b> %v &amp; <a href=\"/cats\">cats</a>&#33;"`
+	}
+
+	factory := New()
+	factory.SetPseudoLocale(PseudoAccented, PseudoOptions{})
+	factory.LoadLocale(PseudoAccented)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething("Gopher"),
+		"[<b>Ĥî</b> Gopher &amp; <a href=\"/cats\">çåţš</a>&#33;]")
+}
+
+func TestPseudoSelect(t *testing.T) {
+	type M struct {
+		MyLittleSomething func(string, string) string `default:"{1, select, female {She met %[2]v} other {They met %[2]v}}!"`
+	}
+
+	factory := New()
+	factory.SetPseudoLocale(PseudoAccented, PseudoOptions{Expansion: 100})
+	factory.LoadLocale(PseudoAccented)
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t,
+		m.MyLittleSomething("female", "Gopher"),
+		"[Šĥé ɱéţ Gopher!~~~~~~~]")
+	testMessage(t,
+		m.MyLittleSomething("male", "Gopher"),
+		"[Ţĥéý ɱéţ Gopher!~~~~~~~]")
+}
+
+func TestPseudoLocalesNotMatched(t *testing.T) {
+	type M struct {
+		Hello func(context.Context) string `default:"Hi"`
+	}
+
+	factory := New()
+	factory.SetLocale(language.English, "json", TempFile(`{}`))
+	factory.SetDefaultLocale(language.English)
+	factory.SetPseudoLocale(PseudoAccented, PseudoOptions{})
+	factory.SetPseudoLocale(PseudoBidi, PseudoOptions{Bidi: true})
+
+	if locales := factory.Locales(); len(locales) != 1 || locales[0] != language.English {
+		t.Errorf("Pseudo-locales are listed in %v.", locales)
+	}
+
+	for _, preferred := range []string{"en-GB", "ar", "ar-EG", "en-XA"} {
+		tag, _ := factory.MatchLocale(language.MustParse(preferred))
+		if tag != language.English {
+			t.Errorf("Locale %v is matched to %v.", preferred, tag)
+		}
+	}
+
+	m := factory.Init(&M{}).(*M)
+
+	testMessage(t, m.Hello(WithLocale(context.Background(), PseudoAccented)), "[Ĥî]")
+
+	factory.LoadLocale(PseudoAccented)
+	testMessage(t, m.Hello(context.Background()), "[Ĥî]")
+}
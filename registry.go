@@ -0,0 +1,118 @@
+package g11n
+
+import (
+	"reflect"
+)
+
+// Descriptor describes a message of an initialized struct.
+type Descriptor struct {
+
+	// Key is the key of the message in the locale files.
+	Key string
+
+	// Struct is the message struct type that declares the message.
+	Struct reflect.Type
+
+	// Field is the name of the message field.
+	Field string
+
+	// Type is the type of the message field.
+	Type reflect.Type
+
+	// Params are the types of the parameters substituted in the message,
+	// leaving out the context of context-aware message funcs.
+	Params []reflect.Type
+
+	// Result is the type of the formatted message.
+	Result reflect.Type
+
+	// Default is the default pattern of the message.
+	Default string
+
+	// Tag is the struct tag of the message field.
+	Tag reflect.StructTag
+
+	Annotation
+}
+
+// newDescriptor describes a message field of a struct.
+func newDescriptor(messageKey string, structType reflect.Type, field reflect.StructField) Descriptor {
+	descriptor := Descriptor{
+		Key:        messageKey,
+		Struct:     structType,
+		Field:      field.Name,
+		Type:       field.Type,
+		Result:     field.Type,
+		Default:    field.Tag.Get(defaultMessageTag),
+		Tag:        field.Tag,
+		Annotation: parseAnnotation(messageKey, field.Tag),
+	}
+
+	if field.Type.Kind() == reflect.Func {
+		descriptor.Result = field.Type.Out(0)
+
+		for i := 0; i < field.Type.NumIn(); i++ {
+			if i == 0 && isContextFunc(field.Type) {
+				continue
+			}
+			descriptor.Params = append(descriptor.Params, field.Type.In(i))
+		}
+	}
+
+	return descriptor
+}
+
+// Messages returns the messages of the structs initialized by a message
+// factory in order of initialization.
+func (mf *MessageFactory) Messages() []Descriptor {
+	mf.messagesMutex.RLock()
+	defer mf.messagesMutex.RUnlock()
+
+	messages := make([]Descriptor, 0, len(mf.messageKeys))
+	for _, messageKey := range mf.messageKeys {
+		messages = append(messages, mf.messages[messageKey])
+	}
+
+	return messages
+}
+
+// message returns the descriptor of an initialized message.
+func (mf *MessageFactory) message(messageKey string) (Descriptor, bool) {
+	mf.messagesMutex.RLock()
+	defer mf.messagesMutex.RUnlock()
+
+	descriptor, ok := mf.messages[messageKey]
+	return descriptor, ok
+}
+
+// compiledDefault returns the compiled default pattern of an initialized
+// message func, or nil.
+func (mf *MessageFactory) compiledDefault(messageKey string) *compiledPattern {
+	mf.messagesMutex.RLock()
+	defer mf.messagesMutex.RUnlock()
+
+	return mf.compiledDefaults[messageKey]
+}
+
+// registerMessage records an initialized message and checks its translation
+// in the active locale. The default pattern of a message func is compiled
+// once and returned, so that its handler, Translate and the errors it
+// returns share it.
+func (mf *MessageFactory) registerMessage(descriptor Descriptor) *compiledPattern {
+	var compiled *compiledPattern
+	if descriptor.Type.Kind() == reflect.Func {
+		compiled = compilePattern(descriptor.Default)
+	}
+
+	mf.messagesMutex.Lock()
+	if _, ok := mf.messages[descriptor.Key]; !ok {
+		mf.messageKeys = append(mf.messageKeys, descriptor.Key)
+	}
+	mf.messages[descriptor.Key] = descriptor
+	mf.compiledDefaults[descriptor.Key] = compiled
+	mf.messagesMutex.Unlock()
+
+	mf.checkMaxLengths(mf.activeDictionary(), descriptor.Key)
+
+	return compiled
+}
@@ -0,0 +1,92 @@
+package g11n_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	. "github.com/sgatev/g11n"
+)
+
+type RegistryEmbedded struct {
+	Bye func() string `default:"Bye!"`
+}
+
+type RegistryMessages struct {
+	*RegistryEmbedded
+
+	Title string                                      `default:"Title" desc:"Page title"`
+	Hello func(context.Context, string, int) SafeHTML `default:"Hi %v, you have %v cats!" maxlen:"40"`
+}
+
+func TestMessages(t *testing.T) {
+	factory := New()
+	factory.Init(&RegistryMessages{})
+
+	stringType := reflect.TypeOf("")
+	embeddedType := reflect.TypeOf(RegistryEmbedded{})
+	messagesType := reflect.TypeOf(RegistryMessages{})
+
+	expected := []Descriptor{
+		{
+			Key:     "RegistryEmbedded.Bye",
+			Struct:  embeddedType,
+			Field:   "Bye",
+			Type:    reflect.TypeOf(func() string { return "" }),
+			Result:  stringType,
+			Default: "Bye!",
+			Tag:     `default:"Bye!"`,
+		},
+		{
+			Key:        "RegistryMessages.Title",
+			Struct:     messagesType,
+			Field:      "Title",
+			Type:       stringType,
+			Result:     stringType,
+			Default:    "Title",
+			Tag:        `default:"Title" desc:"Page title"`,
+			Annotation: Annotation{Description: "Page title"},
+		},
+		{
+			Key:        "RegistryMessages.Hello",
+			Struct:     messagesType,
+			Field:      "Hello",
+			Type:       reflect.TypeOf(func(context.Context, string, int) SafeHTML { return "" }),
+			Params:     []reflect.Type{stringType, reflect.TypeOf(0)},
+			Result:     reflect.TypeOf(SafeHTML("")),
+			Default:    "Hi %v, you have %v cats!",
+			Tag:        `default:"Hi %v, you have %v cats!" maxlen:"40"`,
+			Annotation: Annotation{MaxLength: 40},
+		},
+	}
+
+	if actual := factory.Messages(); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Messages are not correct.\n"+
+			"\tExpected: %+v\n"+
+			"\tActual: %+v\n", expected, actual)
+	}
+}
+
+func TestMessagesInitTwice(t *testing.T) {
+	factory := New()
+	factory.Init(&RegistryEmbedded{})
+	factory.Init(&RegistryMessages{})
+
+	var keys []string
+	for _, message := range factory.Messages() {
+		keys = append(keys, message.Key)
+	}
+
+	expected := []string{"RegistryEmbedded.Bye", "RegistryMessages.Title", "RegistryMessages.Hello"}
+	if !reflect.DeepEqual(keys, expected) {
+		t.Errorf("Message keys are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, keys)
+	}
+}
+
+func TestMessagesEmpty(t *testing.T) {
+	if messages := New().Messages(); len(messages) != 0 {
+		t.Errorf("Messages of a new factory: %v", messages)
+	}
+}
@@ -0,0 +1,93 @@
+package g11n_test
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+type Gender int
+
+const (
+	Female Gender = iota
+	Male
+	Neuter
+)
+
+func (g Gender) G11nSelect() string {
+	switch g {
+	case Female:
+		return "female"
+	case Male:
+		return "male"
+	default:
+		return "other"
+	}
+}
+
+type SelectMessages struct {
+	Arrived func(Gender, string) string `default:"{1, select, female {%[2]v arrived with her cat} other {%[2]v arrived with their cat}}"`
+	Order   func(string, int) string    `default:"{1, select, pizza {%[2]d pizzas} pasta {%[2]d plates of pasta} other {%[2]d dishes}} ordered"`
+	Left    func(Gender) string         `default:"{1, select, female {She} male {He} other {They}} left."`
+}
+
+func TestSelectMessage(t *testing.T) {
+	m := New().Init(&SelectMessages{}).(*SelectMessages)
+
+	testMessage(t, m.Arrived(Female, "Ana"), "Ana arrived with her cat")
+	testMessage(t, m.Arrived(Male, "Ivan"), "Ivan arrived with their cat")
+	testMessage(t, m.Left(Male), "He left.")
+	testMessage(t, m.Left(Neuter), "They left.")
+}
+
+func TestSelectMessageString(t *testing.T) {
+	m := New().Init(&SelectMessages{}).(*SelectMessages)
+
+	testMessage(t, m.Order("pasta", 2), "2 plates of pasta ordered")
+	testMessage(t, m.Order("soup", 3), "3 dishes ordered")
+}
+
+func TestSelectMessageLocalized(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "SelectMessages.Arrived": "{1, select, female {%[2]v дойде с котката си} male {%[2]v дойде с котката си} other {%[2]v дойдоха}}",
+	  "SelectMessages.Left": "{1, select, female {Тя си тръгна} male {Той си тръгна} other {Те си тръгнаха}}."
+	}
+`))
+	factory.LoadLocale(language.Bulgarian)
+
+	m := factory.Init(&SelectMessages{}).(*SelectMessages)
+
+	testMessage(t, m.Left(Female), "Тя си тръгна.")
+	testMessage(t, m.Left(Male), "Той си тръгна.")
+	testMessage(t, m.Arrived(Neuter, "Деца"), "Деца дойдоха")
+}
+
+func TestSelectMessageNested(t *testing.T) {
+	type M struct {
+		Invite func(Gender, Gender) string `default:"{1, select, female {She invites {2, select, female {her} other {them}}} other {They invite {2, select, female {her} other {them}}}}"`
+	}
+
+	m := New().Init(&M{}).(*M)
+
+	testMessage(t, m.Invite(Female, Female), "She invites her")
+	testMessage(t, m.Invite(Male, Female), "They invite her")
+	testMessage(t, m.Invite(Female, Neuter), "She invites them")
+}
+
+func TestSelectMessageWithPlaceholders(t *testing.T) {
+	type M struct {
+		Due func(Gender, time.Time) string `default:"{1, select, female {She is due {2, date, short}} other {They are due {2, date, short}}} at 100%%"`
+	}
+
+	m := New().Init(&M{}).(*M)
+
+	due := time.Date(2020, time.March, 5, 0, 0, 0, 0, time.UTC)
+	testMessage(t, m.Due(Female, due), "She is due 3/5/20 at 100%")
+	testMessage(t, m.Due(Neuter, due), "They are due 3/5/20 at 100%")
+}
@@ -0,0 +1,59 @@
+package g11n
+
+import (
+	"context"
+	"fmt"
+	"text/template"
+)
+
+// Application constants.
+const (
+	templateFuncName = "t"
+)
+
+// Error message patterns.
+const (
+	unknownMessageKey = "Unknown message key '%v'."
+)
+
+// FuncMap returns template funcs that format the messages of the structs
+// initialized by a message factory. The funcs could be added to both
+// text/template and html/template templates.
+//
+// The t func formats a message by its key. The message is formatted in the
+// locale of the context if a context.Context is passed as first argument and
+// in the active locale of the factory otherwise.
+//
+//	{{t "Messages.Hello" .Name}}
+//	{{t "Messages.Hello" .Context .Name}}
+//
+// Messages whose result type is SafeHTML or template.HTML of html/template
+// are not escaped by html/template. Their parameters are HTML-escaped when
+// they are substituted instead.
+func FuncMap(mf *MessageFactory) template.FuncMap {
+	return template.FuncMap{
+		templateFuncName: mf.templateMessage,
+	}
+}
+
+// templateMessage formats a message for a template.
+func (mf *MessageFactory) templateMessage(messageKey string, args ...interface{}) (interface{}, error) {
+	if _, ok := mf.message(messageKey); !ok {
+		return nil, fmt.Errorf(unknownMessageKey, messageKey)
+	}
+
+	dictionary := mf.activeDictionary()
+	if len(args) > 0 {
+		if ctx, ok := args[0].(context.Context); ok {
+			dictionary = mf.contextDictionary(ctx)
+			args = args[1:]
+		}
+	}
+
+	result := mf.translate(dictionary, messageKey, args).Interface()
+	if html, ok := result.(htmlResult); ok {
+		return html.HTML(), nil
+	}
+
+	return result, nil
+}
@@ -0,0 +1,130 @@
+package g11n_test
+
+import (
+	"bytes"
+	"context"
+	htmlTemplate "html/template"
+	"testing"
+	textTemplate "text/template"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+type TemplateMessages struct {
+	Title   string                            `default:"Pets & <friends>"`
+	Hello   func(string) string               `default:"Hi <b>%v</b>!"`
+	Welcome func(string) htmlTemplate.HTML    `default:"Welcome <b>%v</b>!"`
+	Cats    func(context.Context, int) string `default:"%v cats"`
+}
+
+func executeHTML(t *testing.T, factory *MessageFactory, text string, data interface{}) string {
+	tmpl := htmlTemplate.Must(htmlTemplate.New("test").Funcs(FuncMap(factory)).Parse(text))
+
+	var result bytes.Buffer
+	if err := tmpl.Execute(&result, data); err != nil {
+		t.Fatalf("Template execution failed: %v", err)
+	}
+
+	return result.String()
+}
+
+func TestTextTemplate(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "TemplateMessages.Title": "Любимци",
+	  "TemplateMessages.Cats": "%v котки"
+	}
+`))
+
+	factory.Init(&TemplateMessages{})
+
+	tmpl := textTemplate.Must(textTemplate.New("test").Funcs(FuncMap(factory)).Parse(
+		`{{t "TemplateMessages.Title"}}: {{t "TemplateMessages.Hello" .}}`))
+
+	var result bytes.Buffer
+	if err := tmpl.Execute(&result, "Bob"); err != nil {
+		t.Fatalf("Template execution failed: %v", err)
+	}
+
+	testMessage(t, result.String(), "Pets & <friends>: Hi <b>Bob</b>!")
+}
+
+func TestHTMLTemplateEscaping(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "TemplateMessages.Title": "Любимци",
+	  "TemplateMessages.Cats": "%v котки"
+	}
+`))
+
+	factory.Init(&TemplateMessages{})
+
+	testMessage(t,
+		executeHTML(t, factory, `{{t "TemplateMessages.Hello" .}}`, "Bob"),
+		"Hi &lt;b&gt;Bob&lt;/b&gt;!")
+	testMessage(t,
+		executeHTML(t, factory, `{{t "TemplateMessages.Welcome" .}}`, "Bob"),
+		"Welcome <b>Bob</b>!")
+	testMessage(t,
+		executeHTML(t, factory, `{{t "TemplateMessages.Welcome" .}}`, "<script>x</script>"),
+		"Welcome <b>&lt;script&gt;x&lt;/script&gt;</b>!")
+}
+
+func TestTemplateActiveLocale(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "TemplateMessages.Title": "Любимци",
+	  "TemplateMessages.Cats": "%v котки"
+	}
+`))
+
+	factory.Init(&TemplateMessages{})
+	factory.LoadLocale(language.Bulgarian)
+
+	testMessage(t,
+		executeHTML(t, factory, `{{t "TemplateMessages.Title"}}`, nil),
+		"Любимци")
+}
+
+func TestTemplateContextLocale(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "TemplateMessages.Title": "Любимци",
+	  "TemplateMessages.Cats": "%v котки"
+	}
+`))
+
+	factory.Init(&TemplateMessages{})
+
+	data := map[string]interface{}{
+		"Context": WithLocale(context.Background(), language.Bulgarian),
+		"Count":   3,
+	}
+
+	testMessage(t,
+		executeHTML(t, factory, `{{t "TemplateMessages.Cats" .Context .Count}}`, data),
+		"3 котки")
+	testMessage(t,
+		executeHTML(t, factory, `{{t "TemplateMessages.Cats" .Count}}`, data),
+		"3 cats")
+}
+
+func TestTemplateUnknownKey(t *testing.T) {
+	tmpl := htmlTemplate.Must(htmlTemplate.New("test").Funcs(FuncMap(New())).Parse(
+		`{{t "TemplateMessages.Missing"}}`))
+
+	err := tmpl.Execute(&bytes.Buffer{}, nil)
+	if err == nil {
+		t.Fatalf("Template execution did not fail.")
+	}
+
+	testMessage(t, err.Error(),
+		`template: test:1:2: executing "test" at <t "TemplateMessages.Missing">: error calling t: Unknown message key 'TemplateMessages.Missing'.`)
+}
@@ -0,0 +1,89 @@
+package g11n
+
+import (
+	"context"
+	"reflect"
+)
+
+// stringType is the reflected type of string.
+var stringType = reflect.TypeOf("")
+
+// Default is a message pattern that is passed as first argument of Translate
+// and TranslateContext. It is used when the locale has no translation of
+// the message.
+type Default string
+
+// Translate formats a message by its key in the active locale, e.g. a key
+// that comes from configuration or a database.
+//
+// The message is looked up in the same way as the messages of initialized
+// structs. Without a translation, the pattern of a Default passed as first
+// argument is used, then the default pattern of an initialized message with
+// the same key and finally the key itself. Initialized messages are also
+// formatted by their result type.
+//
+//	G.Translate("Errors.DiskFull", g11n.Default("Disk %v is full."), disk)
+func (mf *MessageFactory) Translate(messageKey string, args ...interface{}) string {
+	return mf.translate(mf.activeDictionary(), messageKey, args).String()
+}
+
+// TranslateContext formats a message by its key like Translate in the
+// locale of a context.
+func (mf *MessageFactory) TranslateContext(ctx context.Context, messageKey string, args ...interface{}) string {
+	return mf.translate(mf.contextDictionary(ctx), messageKey, args).String()
+}
+
+// interfaceValues reflects arguments as values of interface type, so that
+// nil arguments are valid values.
+func interfaceValues(args []interface{}) []reflect.Value {
+	values := make([]reflect.Value, len(args))
+	for i := range args {
+		values[i] = reflect.ValueOf(&args[i]).Elem()
+	}
+
+	return values
+}
+
+// translate formats a message by its key in the locale of a dictionary.
+func (mf *MessageFactory) translate(dictionary *dictionary, messageKey string, args []interface{}) reflect.Value {
+	defaultPattern := messageKey
+	resultType := stringType
+	isFunc := true
+
+	// The default patterns of initialized messages are compiled once.
+	var compiledDefault *compiledPattern
+
+	if descriptor, ok := mf.message(messageKey); ok {
+		defaultPattern = descriptor.Default
+		resultType = descriptor.Result
+		isFunc = descriptor.Type.Kind() == reflect.Func
+		compiledDefault = mf.compiledDefault(messageKey)
+	}
+
+	if len(args) > 0 {
+		if inlineDefault, ok := args[0].(Default); ok {
+			defaultPattern = string(inlineDefault)
+			compiledDefault = nil
+			args = args[1:]
+		}
+	}
+
+	values := interfaceValues(args)
+
+	message := dictionary.lookup(messageKey, defaultPattern)
+	if isFunc {
+		if compiledDefault == nil {
+			compiledDefault = compilePattern(defaultPattern)
+		}
+
+		messagePattern := dictionary.lookupPattern(messageKey, compiledDefault)
+		message, _ = dictionary.format(messagePattern, values, resultEscaper(resultType))
+	}
+
+	// Localized errors and lazy messages are translated to their message.
+	if isErrorResult(resultType) || resultType == messageType {
+		resultType = stringType
+	}
+
+	return formatResult(message, resultType, dictionary.tag, messageKey, values)
+}
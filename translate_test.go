@@ -0,0 +1,90 @@
+package g11n_test
+
+import (
+	"context"
+	"testing"
+
+	"golang.org/x/text/language"
+
+	. "github.com/sgatev/g11n"
+	. "github.com/sgatev/g11n/test"
+)
+
+type TranslateMessages struct {
+	Hello func(string) SafeHTML `default:"Hi <b>%v</b>!"`
+	Title string                `default:"100% cats"`
+}
+
+func TestTranslate(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "Errors.DiskFull": "Дискът %v е пълен.",
+	  "TranslateMessages.Hello": "Здравей <b>%v</b>!"
+	}
+`))
+	factory.LoadLocale(language.Bulgarian)
+
+	testMessage(t,
+		factory.Translate("Errors.DiskFull", Default("Disk %v is full."), "C"),
+		"Дискът C е пълен.")
+	testMessage(t,
+		factory.Translate("Errors.NoSpace", Default("No space left on %v."), "C"),
+		"No space left on C.")
+	testMessage(t,
+		factory.Translate("Errors.Unknown"),
+		"Errors.Unknown")
+}
+
+func TestTranslateInitializedMessage(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "Errors.DiskFull": "Дискът %v е пълен.",
+	  "TranslateMessages.Hello": "Здравей <b>%v</b>!"
+	}
+`))
+	factory.Init(&TranslateMessages{})
+
+	testMessage(t,
+		factory.Translate("TranslateMessages.Hello", "<Bob>"),
+		"Hi <b>&lt;Bob&gt;</b>!")
+	testMessage(t,
+		factory.Translate("TranslateMessages.Title"),
+		"100% cats")
+
+	factory.LoadLocale(language.Bulgarian)
+
+	testMessage(t,
+		factory.Translate("TranslateMessages.Hello", "Иван"),
+		"Здравей <b>Иван</b>!")
+}
+
+func TestTranslateFormatsParams(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.German, "json", TempFile(`{}`))
+	factory.LoadLocale(language.German)
+
+	testMessage(t,
+		factory.Translate("Stats.Cats", Default("%d cats, %v"), 12345, CustomFormat{func() string { return "custom" }}),
+		"12.345 cats, custom")
+}
+
+func TestTranslateContext(t *testing.T) {
+	factory := New()
+	factory.SetLocale(language.Bulgarian, "json", TempFile(`
+	{
+	  "Errors.DiskFull": "Дискът %v е пълен.",
+	  "TranslateMessages.Hello": "Здравей <b>%v</b>!"
+	}
+`))
+
+	ctx := WithLocale(context.Background(), language.Bulgarian)
+
+	testMessage(t,
+		factory.TranslateContext(ctx, "Errors.DiskFull", Default("Disk %v is full."), "C"),
+		"Дискът C е пълен.")
+	testMessage(t,
+		factory.TranslateContext(context.Background(), "Errors.DiskFull", Default("Disk %v is full."), "C"),
+		"Disk C is full.")
+}
@@ -0,0 +1,45 @@
+package a
+
+import "github.com/sgatev/g11n"
+
+type N struct {
+	Embedded func(string) string `default:"Embedded"` // want `default message of Embedded has 0 placeholders, the func has 1 parameters`
+}
+
+type O struct {
+	Other func() string
+}
+
+//g11n:messages
+type Messages struct {
+	*N
+	O // want `embedded message struct O must be a pointer`
+
+	Title    string               `default:"Title"`
+	Hello    func(string) string  `default:"Hi %v!"`
+	Answer   func(string) string  `default:"The answer to %v is %v."` // want `default message of Answer has 2 placeholders, the func has 1 parameters`
+	Results  func() (string, int) `default:"Oops!"`                   // want `message func Results has 2 results, expected 1`
+	Count    int                  `default:"Count"`                   // want `message field Count of type int must be a string or a func`
+	Untagged func(int) string
+	Pay      func() string                          `default:"Pay" maxlen:"5"`
+	Checkout string                                 `default:"Checkout" maxlen:"5"`   // want `default message of Checkout is 8 characters long, the maxlen is 5`
+	Cancel   func() string                          `default:"Cancel" maxlen:"short"` // want `maxlen of Cancel must be a positive number, got "short"`
+	Friends  func(string, ...string) string         `default:"%v, %v and %v"`
+	Nobody   func(string, string, ...string) string `default:"%v"` // want `default message of Nobody has 1 placeholders, the func has 3 parameters`
+	Strict   func(string) (string, error)           `default:"Hi %v!"`
+	Lenient  func(string) (string, error)           `default:"Hi!"` // want `default message of Lenient has 0 placeholders, the func has 1 parameters`
+}
+
+type Initialized struct {
+	Reordered func(string, int) string `default:"%[2]v %[1]v"`
+	Answer    func() (string, int)     // want `message func Answer has 2 results, expected 1`
+}
+
+// Config is not a message struct, though its fields have default tags.
+type Config struct {
+	Port int `default:"8080"`
+}
+
+func init() {
+	g11n.New().Init(&Initialized{})
+}
@@ -0,0 +1,11 @@
+package g11n
+
+type MessageFactory struct{}
+
+func New() *MessageFactory {
+	return &MessageFactory{}
+}
+
+func (mf *MessageFactory) Init(structPtr interface{}) interface{} {
+	return structPtr
+}
@@ -0,0 +1,141 @@
+// Package vet defines an analyzer that reports g11n message structs which
+// MessageFactory.Init would reject or render incorrectly at runtime.
+//
+// The analyzer checks that
+//
+//   - message funcs return a message and an optional error,
+//   - the default pattern of a message func has as many verbs as parameters,
+//     or at least as many as its non-variadic parameters,
+//   - embedded message structs are pointers,
+//   - message fields are either strings or funcs,
+//   - maxlen tags are positive numbers that the default pattern fits in.
+package vet
+
+import (
+	"go/token"
+	"go/types"
+	"unicode/utf8"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/sgatev/g11n/internal/scan"
+	"github.com/sgatev/g11n/pattern"
+)
+
+// Diagnostic message patterns.
+const (
+	wrongResultsCountMessage  = "message func %v has %v results, expected 1 or a message and an error"
+	wrongPlaceholdersMessage  = "default message of %v has %v placeholders, the func has %v parameters"
+	nonPointerEmbeddedMessage = "embedded message struct %v must be a pointer"
+	unsupportedFieldMessage   = "message field %v of type %v must be a string or a func"
+	wrongMaxLengthMessage     = "maxlen of %v must be a positive number, got %q"
+	maxLengthExceededMessage  = "default message of %v is %v characters long, the maxlen is %v"
+)
+
+// Application constants.
+const (
+	defaultMessageTag = "default"
+	maxLengthTag      = "maxlen"
+)
+
+const doc = `check g11n message structs
+
+The g11n analyzer reports message structs that MessageFactory.Init
+would reject at runtime: message funcs that do not have exactly one result,
+default patterns whose verbs do not match the func parameters, embedded
+message structs that are not pointers, fields that are neither strings
+nor funcs and malformed or exceeded maxlen tags.`
+
+// Analyzer checks g11n message structs.
+var Analyzer = &analysis.Analyzer{
+	Name: "g11n",
+	Doc:  doc,
+	Run:  run,
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	checked := map[token.Pos]bool{}
+
+	for _, named := range scan.Find(pass.Pkg, pass.TypesInfo, pass.Files) {
+		if s := scan.Load(named); s != nil {
+			checkStruct(pass, s, checked)
+		}
+	}
+
+	return nil, nil
+}
+
+// checkStruct reports the problems of the fields of a message struct
+// declared in the analyzed package.
+func checkStruct(pass *analysis.Pass, s *scan.Struct, checked map[token.Pos]bool) {
+	if s.Named.Obj().Pkg() != pass.Pkg {
+		return
+	}
+
+	for _, field := range s.Fields {
+		if checked[field.Var.Pos()] {
+			continue
+		}
+		checked[field.Var.Pos()] = true
+
+		switch field.Kind {
+		case scan.EmbeddedField:
+			checkStruct(pass, field.Embedded, checked)
+		case scan.StringField:
+			checkMaxLength(pass, field)
+		case scan.FuncField:
+			checkFunc(pass, field)
+			checkMaxLength(pass, field)
+		case scan.InvalidField:
+			if field.Var.Anonymous() {
+				pass.Reportf(field.Var.Pos(), nonPointerEmbeddedMessage, field.Name())
+			} else {
+				pass.Reportf(field.Var.Pos(), unsupportedFieldMessage,
+					field.Name(), types.TypeString(field.Var.Type(), types.RelativeTo(pass.Pkg)))
+			}
+		}
+	}
+}
+
+// checkFunc reports the problems of a message func.
+func checkFunc(pass *analysis.Pass, field *scan.Field) {
+	signature := field.Signature
+
+	if results := signature.Results().Len(); results != 1 && !field.ReturnsError() {
+		pass.Reportf(field.Var.Pos(), wrongResultsCountMessage, field.Name(), results)
+	}
+
+	if _, ok := field.Tag.Lookup(defaultMessageTag); !ok {
+		return
+	}
+
+	// The variadic parameter of a message func is expanded into any number
+	// of arguments.
+	params := len(field.Params())
+	placeholders := pattern.Count(field.Default)
+	if signature.Variadic() && placeholders >= params-1 {
+		return
+	}
+
+	if placeholders != params {
+		pass.Reportf(field.Var.Pos(), wrongPlaceholdersMessage, field.Name(), placeholders, params)
+	}
+}
+
+// checkMaxLength reports malformed maxlen tags and default patterns that
+// are longer than their maxlen.
+func checkMaxLength(pass *analysis.Pass, field *scan.Field) {
+	maxLength, ok := field.Tag.Lookup(maxLengthTag)
+	if !ok {
+		return
+	}
+
+	if field.MaxLength == 0 {
+		pass.Reportf(field.Var.Pos(), wrongMaxLengthMessage, field.Name(), maxLength)
+		return
+	}
+
+	if length := utf8.RuneCountInString(field.Default); length > field.MaxLength {
+		pass.Reportf(field.Var.Pos(), maxLengthExceededMessage, field.Name(), length, field.MaxLength)
+	}
+}
@@ -0,0 +1,98 @@
+package vet_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	. "github.com/sgatev/g11n/vet"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a")
+}
+
+// contextSource stubs package context, which is slow to load from source.
+const contextSource = `
+package context
+
+type Context interface {
+	Done() <-chan struct{}
+	Err() error
+	Value(key interface{}) interface{}
+}
+`
+
+const contextMessagesSource = `
+package b
+
+import "context"
+
+//g11n:messages
+type Messages struct {
+	Greet   func(context.Context, string) string ` + "`default:\"Hi %v!\"`" + `
+	Welcome func(context.Context) string         ` + "`default:\"Welcome %v!\"`" + `
+}
+`
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
+
+func typeCheck(t *testing.T, fset *token.FileSet, path, source string, imp types.Importer) (*types.Package, *types.Info, []*ast.File) {
+	file, err := parser.ParseFile(fset, path+".go", source, parser.ParseComments)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	info := &types.Info{
+		Types: map[ast.Expr]types.TypeAndValue{},
+		Defs:  map[*ast.Ident]types.Object{},
+		Uses:  map[*ast.Ident]types.Object{},
+	}
+	config := types.Config{Importer: imp}
+	pkg, err := config.Check(path, fset, []*ast.File{file}, info)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pkg, info, []*ast.File{file}
+}
+
+func TestAnalyzerContextFuncs(t *testing.T) {
+	fset := token.NewFileSet()
+	context, _, _ := typeCheck(t, fset, "context", contextSource, nil)
+	pkg, info, files := typeCheck(t, fset, "b", contextMessagesSource, importerFunc(func(path string) (*types.Package, error) {
+		return context, nil
+	}))
+
+	var actual []string
+	pass := &analysis.Pass{
+		Analyzer:  Analyzer,
+		Fset:      fset,
+		Files:     files,
+		Pkg:       pkg,
+		TypesInfo: info,
+		Report: func(diagnostic analysis.Diagnostic) {
+			actual = append(actual, diagnostic.Message)
+		},
+	}
+	if _, err := Analyzer.Run(pass); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"default message of Welcome has 1 placeholders, the func has 0 parameters"}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Diagnostics are not correct.\n"+
+			"\tExpected: %v\n"+
+			"\tActual: %v\n", expected, actual)
+	}
+}